@@ -12,14 +12,53 @@ type Client interface {
 	// Set creates or updates a key-value with options.
 	Set(ctx context.Context, key string, value []byte, opts ...SetOption) error
 
+	// SetMany atomically creates or updates multiple key-values with options.
+	SetMany(ctx context.Context, items []SetItem) error
+
+	// Create creates a key-value with a key consisting of the prefix followed by the next zero-padded
+	// sequence number of the prefix and returns the key. Keys are created in sequence order. Only the
+	// lease options (WithLeaseID, WithExpiresAt, WithEphemeral) apply.
+	Create(ctx context.Context, prefix string, value []byte, opts ...SetOption) (string, error)
+
+	// Increment atomically adds the delta to the integer value of the key-value and returns the new value.
+	// Values are stored as decimal strings, it returns ErrNotInteger if the existing value isn't an integer.
+	// Non-existent key-values are created with an initial value of delta.
+	Increment(ctx context.Context, key string, delta int64) (int64, error)
+
 	// Delete soft-deletes the key-value for the given key. It will not be returned in Get or List.
-	Delete(ctx context.Context, key string) error
+	Delete(ctx context.Context, key string, opts ...DeleteOption) error
+
+	// DeletePrefix soft-deletes all key-values with keys matching the prefix and returns the number
+	// of deleted key-values. Key-values are deleted in batches, each in its own transaction, so
+	// the delete is not atomic. The prefix may not be empty.
+	DeletePrefix(ctx context.Context, prefix string, opts ...DeletePrefixOption) (int64, error)
 
 	// Get returns the key-value struct for the given key.
 	Get(ctx context.Context, key string) (KV, error)
 
-	// List returns all key-values with keys matching the prefix.
-	List(ctx context.Context, prefix string) ([]KV, error)
+	// GetMany returns the key-values for the given keys in the same order as the keys. A zero KV
+	// is returned for keys that are not found.
+	GetMany(ctx context.Context, keys []string) ([]KV, error)
+
+	// List returns key-values with keys matching the prefix ordered by key. It returns all
+	// matching key-values by default, use WithLimit, WithCursor and WithStartAfter to page through large prefixes.
+	List(ctx context.Context, prefix string, opts ...ListOption) ([]KV, error)
+
+	// ListSnapshot returns a consistent snapshot of all key-values with keys matching the prefix and
+	// the refs of the events it reflects. See Watch to stream the subsequent events.
+	ListSnapshot(ctx context.Context, prefix string) (Snapshot, error)
+
+	// GetAt returns the key-value struct for the given key as it was after the event with id ref.
+	// Note that LeaseID is not populated.
+	GetAt(ctx context.Context, key string, ref int64) (KV, error)
+
+	// ListAt returns all key-values with keys matching the prefix ordered by key as they were
+	// after the event with id ref. Note that LeaseID is not populated.
+	ListAt(ctx context.Context, prefix string, ref int64) ([]KV, error)
+
+	// History returns the revisions (set, delete and expire events) of the given key ordered by ref.
+	// Note that values of revisions superseded before the event log compaction ref are empty.
+	History(ctx context.Context, key string, opts ...HistoryOption) ([]Revision, error)
 
 	// UpdateLease updates the expires_at field of the given lease. A zero expires at
 	// implies no expiry.
@@ -28,8 +67,30 @@ type Client interface {
 	// ExpireLease expires the given lease and deletes all key-values associated with it.
 	ExpireLease(ctx context.Context, leaseID int64) error
 
+	// GrantLease creates a new lease without any key-values and returns its id. A zero
+	// expires at implies no expiry. Key-values are associated with it via WithLeaseID.
+	GrantLease(ctx context.Context, expiresAt time.Time) (int64, error)
+
+	// GetLease returns the lease for the given id.
+	GetLease(ctx context.Context, leaseID int64) (Lease, error)
+
+	// ListLeaseKeys returns all key-values associated with the given lease.
+	ListLeaseKeys(ctx context.Context, leaseID int64) ([]KV, error)
+
+	// ListLeases returns leases ordered by id filtered by the options. It returns
+	// all non-expired leases by default.
+	ListLeases(ctx context.Context, opts ...ListLeasesOption) ([]Lease, error)
+
 	// Stream returns a reflex stream function filtering events for keys matching the prefix.
 	Stream(prefix string) reflex.StreamFunc
+
+	// WatchStream returns a stream function of typed events for keys matching the prefix. Unlike
+	// Stream, events include the version and lease id of the key-value.
+	WatchStream(prefix string) WatchStreamFunc
+
+	// Txn atomically executes the "then" operations if all the compares are true,
+	// otherwise it executes the "else" operations.
+	Txn(ctx context.Context, compares []Compare, then []Op, els []Op) (TxnResponse, error)
 }
 
 type KV struct {
@@ -56,6 +117,55 @@ type KV struct {
 	LeaseID int64
 }
 
+type Lease struct {
+	// ID of the lease.
+	ID int64
+
+	// ExpiresAt is the time the lease (and its key-values) will be expired. If zero, the lease doesn't expire.
+	ExpiresAt time.Time
+
+	// Version is incremented each time the lease is updated.
+	Version int64
+
+	// Expired is true if the lease has been expired.
+	Expired bool
+}
+
+// Revision is a historical revision of a key-value associated with an event.
+type Revision struct {
+	// Key of the key-value.
+	Key string
+
+	// Type of the event; set, delete or expire.
+	Type EventType
+
+	// Ref is the id of the event.
+	Ref int64
+
+	// Timestamp of the event.
+	Timestamp time.Time
+
+	// Value of the key-value after the event. It is always empty for delete and expire events.
+	Value []byte
+}
+
+// SetItem is a key-value with options set by SetMany.
+type SetItem struct {
+	Key        string
+	Value      []byte
+	SetOptions SetOptions
+}
+
+// NewSetItem returns a SetItem of the key-value with options.
+func NewSetItem(key string, value []byte, opts ...SetOption) SetItem {
+	var o SetOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return SetItem{Key: key, Value: value, SetOptions: o}
+}
+
 type EventType int
 
 func (t EventType) ReflexType() int {
@@ -24,6 +24,32 @@ type Client struct {
 }
 
 func (c Client) Set(ctx context.Context, key string, value []byte, opts ...goku.SetOption) error {
+	req, err := pb.SetItemToProto(goku.NewSetItem(key, value, opts...))
+	if err != nil {
+		return err
+	}
+
+	_, err = c.clpb.Set(ctx, req)
+
+	return err
+}
+
+func (c Client) SetMany(ctx context.Context, items []goku.SetItem) error {
+	req := new(pb.SetManyRequest)
+	for _, item := range items {
+		ipb, err := pb.SetItemToProto(item)
+		if err != nil {
+			return err
+		}
+		req.Items = append(req.Items, ipb)
+	}
+
+	_, err := c.clpb.SetMany(ctx, req)
+
+	return err
+}
+
+func (c Client) Create(ctx context.Context, prefix string, value []byte, opts ...goku.SetOption) (string, error) {
 	var o goku.SetOptions
 	for _, opt := range opts {
 		opt(&o)
@@ -31,24 +57,70 @@ func (c Client) Set(ctx context.Context, key string, value []byte, opts ...goku.
 
 	expiresAt, err := ptypes.TimestampProto(o.ExpiresAt)
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	resp, err := c.clpb.Create(ctx, &pb.CreateRequest{
+		Prefix:    prefix,
+		Value:     value,
+		ExpiresAt: expiresAt,
+		LeaseId:   o.LeaseID,
+		Ephemeral: o.Ephemeral,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Key, nil
+}
+
+func (c Client) Increment(ctx context.Context, key string, delta int64) (int64, error) {
+	resp, err := c.clpb.Increment(ctx, &pb.IncrementRequest{Key: key, Delta: delta})
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.Value, nil
+}
+
+func (c Client) Delete(ctx context.Context, key string, opts ...goku.DeleteOption) error {
+	var o goku.DeleteOptions
+	for _, opt := range opts {
+		opt(&o)
 	}
 
-	_, err = c.clpb.Set(ctx, &pb.SetRequest{
+	resp, err := c.clpb.Delete(ctx, &pb.DeleteRequest{
 		Key:         key,
-		Value:       value,
-		ExpiresAt:   expiresAt,
-		LeaseId:     o.LeaseID,
 		PrevVersion: o.PrevVersion,
-		CreateOnly:  o.CreateOnly,
+		LeaseId:     o.LeaseID,
+		ReturnPrev:  o.Prev != nil,
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	if o.Prev != nil && resp.Prev != nil {
+		*o.Prev = pb.FromProto(resp.Prev)
+	}
+
+	return nil
 }
 
-func (c Client) Delete(ctx context.Context, key string) error {
-	_, err := c.clpb.Delete(ctx, &pb.DeleteRequest{Key: key})
-	return err
+func (c Client) DeletePrefix(ctx context.Context, prefix string, opts ...goku.DeletePrefixOption) (int64, error) {
+	var o goku.DeletePrefixOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	resp, err := c.clpb.DeletePrefix(ctx, &pb.DeletePrefixRequest{
+		Prefix:    prefix,
+		BatchSize: o.BatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.Deleted, nil
 }
 
 func (c Client) Get(ctx context.Context, key string) (goku.KV, error) {
@@ -60,8 +132,34 @@ func (c Client) Get(ctx context.Context, key string) (goku.KV, error) {
 	return pb.FromProto(kv), nil
 }
 
-func (c Client) List(ctx context.Context, prefix string) ([]goku.KV, error) {
-	lcl, err := c.clpb.List(ctx, &pb.ListRequest{Prefix: prefix})
+func (c Client) GetMany(ctx context.Context, keys []string) ([]goku.KV, error) {
+	resp, err := c.clpb.GetMany(ctx, &pb.GetManyRequest{Keys: keys})
+	if err != nil {
+		return nil, err
+	}
+
+	var res []goku.KV
+	for _, kv := range resp.Kvs {
+		res = append(res, pb.FromProto(kv))
+	}
+
+	return res, nil
+}
+
+func (c Client) List(ctx context.Context, prefix string, opts ...goku.ListOption) ([]goku.KV, error) {
+	var o goku.ListOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	lcl, err := c.clpb.List(ctx, &pb.ListRequest{
+		Prefix:     prefix,
+		Limit:      o.Limit,
+		StartAfter: o.StartAfter,
+		EndKey:     o.EndKey,
+		Reverse:    o.Reverse,
+		KeysOnly:   o.KeysOnly,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -77,6 +175,99 @@ func (c Client) List(ctx context.Context, prefix string) ([]goku.KV, error) {
 		res = append(res, pb.FromProto(kv))
 	}
 
+	o.SetCursor(res)
+
+	return res, nil
+}
+
+func (c Client) GetAt(ctx context.Context, key string, ref int64) (goku.KV, error) {
+	kv, err := c.clpb.GetAt(ctx, &pb.GetAtRequest{Key: key, Ref: ref})
+	if err != nil {
+		return goku.KV{}, err
+	}
+
+	return pb.FromProto(kv), nil
+}
+
+func (c Client) ListSnapshot(ctx context.Context, prefix string) (goku.Snapshot, error) {
+	lcl, err := c.clpb.ListSnapshot(ctx, &pb.ListSnapshotRequest{Prefix: prefix})
+	if err != nil {
+		return goku.Snapshot{}, err
+	}
+
+	var res goku.Snapshot
+	for {
+		resp, err := lcl.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return goku.Snapshot{}, err
+		}
+
+		if resp.Kv == nil {
+			res.Ref = resp.Ref
+			res.Pending = resp.Pending
+			continue
+		}
+
+		res.KVs = append(res.KVs, pb.FromProto(resp.Kv))
+	}
+
+	return res, nil
+}
+
+func (c Client) ListAt(ctx context.Context, prefix string, ref int64) ([]goku.KV, error) {
+	lcl, err := c.clpb.ListAt(ctx, &pb.ListAtRequest{Prefix: prefix, Ref: ref})
+	if err != nil {
+		return nil, err
+	}
+
+	var res []goku.KV
+	for {
+		kv, err := lcl.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		res = append(res, pb.FromProto(kv))
+	}
+
+	return res, nil
+}
+
+func (c Client) History(ctx context.Context, key string, opts ...goku.HistoryOption) ([]goku.Revision, error) {
+	var o goku.HistoryOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	hcl, err := c.clpb.History(ctx, &pb.HistoryRequest{
+		Key:      key,
+		Limit:    o.Limit,
+		AfterRef: o.AfterRef,
+		Reverse:  o.Reverse,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res []goku.Revision
+	for {
+		rpb, err := hcl.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		r, err := pb.RevisionFromProto(rpb)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, r)
+	}
+
 	return res, nil
 }
 
@@ -100,6 +291,124 @@ func (c *Client) ExpireLease(ctx context.Context, leaseID int64) error {
 	return err
 }
 
+func (c *Client) GrantLease(ctx context.Context, expiresAt time.Time) (int64, error) {
+	expiresPB, err := ptypes.TimestampProto(expiresAt)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.clpb.GrantLease(ctx, &pb.GrantLeaseRequest{ExpiresAt: expiresPB})
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.LeaseId, nil
+}
+
+func (c *Client) GetLease(ctx context.Context, leaseID int64) (goku.Lease, error) {
+	l, err := c.clpb.GetLease(ctx, &pb.GetLeaseRequest{LeaseId: leaseID})
+	if err != nil {
+		return goku.Lease{}, err
+	}
+
+	return pb.LeaseFromProto(l)
+}
+
+func (c *Client) ListLeaseKeys(ctx context.Context, leaseID int64) ([]goku.KV, error) {
+	lcl, err := c.clpb.ListLeaseKeys(ctx, &pb.ListLeaseKeysRequest{LeaseId: leaseID})
+	if err != nil {
+		return nil, err
+	}
+
+	var res []goku.KV
+	for {
+		kv, err := lcl.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		res = append(res, pb.FromProto(kv))
+	}
+
+	return res, nil
+}
+
+func (c *Client) ListLeases(ctx context.Context, opts ...goku.ListLeasesOption) ([]goku.Lease, error) {
+	var o goku.ListLeasesOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	expiresBefore, err := ptypes.TimestampProto(o.ExpiresBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	lcl, err := c.clpb.ListLeases(ctx, &pb.ListLeasesRequest{
+		ExpiresBefore:  expiresBefore,
+		IncludeExpired: o.IncludeExpired,
+		AfterId:        o.AfterID,
+		Limit:          o.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res []goku.Lease
+	for {
+		lpb, err := lcl.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		l, err := pb.LeaseFromProto(lpb)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, l)
+	}
+
+	return res, nil
+}
+
+func (c *Client) Txn(ctx context.Context, compares []goku.Compare, then []goku.Op, els []goku.Op) (goku.TxnResponse, error) {
+	req := new(pb.TxnRequest)
+	for _, cmp := range compares {
+		req.Compares = append(req.Compares, pb.CompareToProto(cmp))
+	}
+
+	for _, op := range then {
+		o, err := pb.OpToProto(op)
+		if err != nil {
+			return goku.TxnResponse{}, err
+		}
+		req.Then = append(req.Then, o)
+	}
+
+	for _, op := range els {
+		o, err := pb.OpToProto(op)
+		if err != nil {
+			return goku.TxnResponse{}, err
+		}
+		req.Else = append(req.Else, o)
+	}
+
+	resp, err := c.clpb.Txn(ctx, req)
+	if err != nil {
+		return goku.TxnResponse{}, err
+	}
+
+	res := goku.TxnResponse{Succeeded: resp.Succeeded}
+	for _, kv := range resp.Gets {
+		res.Gets = append(res.Gets, pb.FromProto(kv))
+	}
+
+	return res, nil
+}
+
 func (c Client) Stream(prefix string) reflex.StreamFunc {
 	return func(ctx context.Context, after string,
 		opts ...reflex.StreamOption) (reflex.StreamClient, error) {
@@ -118,3 +427,54 @@ func (c Client) Stream(prefix string) reflex.StreamFunc {
 		return sFn(ctx, after, opts...)
 	}
 }
+
+func (c Client) WatchStream(prefix string) goku.WatchStreamFunc {
+	return func(ctx context.Context, after string,
+		opts ...reflex.StreamOption) (goku.WatchStreamClient, error) {
+
+		wcl, err := c.clpb.Watch(ctx, &pb.WatchRequest{
+			Prefix: prefix,
+			Req: &reflexpb.StreamRequest{
+				After:   after,
+				Options: streamOptsToProto(opts),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &watchClient{wcl: wcl}, nil
+	}
+}
+
+// streamOptsToProto returns the proto message of the stream options.
+func streamOptsToProto(opts []reflex.StreamOption) *reflexpb.StreamOptions {
+	var o reflex.StreamOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	res := &reflexpb.StreamOptions{
+		FromHead: o.StreamFromHead,
+		ToHead:   o.StreamToHead,
+	}
+
+	if o.Lag > 0 {
+		res.Lag = ptypes.DurationProto(o.Lag)
+	}
+
+	return res
+}
+
+type watchClient struct {
+	wcl pb.Goku_WatchClient
+}
+
+func (c *watchClient) Recv() (goku.WatchEvent, error) {
+	e, err := c.wcl.Recv()
+	if err != nil {
+		return goku.WatchEvent{}, err
+	}
+
+	return pb.WatchEventFromProto(e)
+}
@@ -2,30 +2,21 @@ package logical
 
 import (
 	"context"
-	"database/sql"
-	"strings"
 	"time"
 
 	"github.com/corverroos/goku"
-	"github.com/corverroos/goku/db"
 	"github.com/luno/reflex"
 )
 
 var _ goku.Client = (*Client)(nil)
 
-func New(wdbc, rdbc *sql.DB) *Client {
-	if rdbc == nil {
-		rdbc = wdbc
-	}
-
-	return &Client{
-		wdbc: wdbc,
-		rdbc: rdbc,
-	}
+// New returns a goku client calling the store directly, see db.NewStore.
+func New(store goku.Store) *Client {
+	return &Client{store: store}
 }
 
 type Client struct {
-	wdbc, rdbc *sql.DB
+	store goku.Store
 }
 
 func (c *Client) Set(ctx context.Context, key string, value []byte, opts ...goku.SetOption) error {
@@ -34,32 +25,110 @@ func (c *Client) Set(ctx context.Context, key string, value []byte, opts ...goku
 		opt(&o)
 	}
 
-	return db.Set(ctx, c.wdbc, db.SetReq{
-		Key:         key,
-		Value:       value,
-		ExpiresAt:   o.ExpiresAt,
-		LeaseID:     o.LeaseID,
-		PrevVersion: o.PrevVersion,
-		CreateOnly:  o.CreateOnly,
-	})
+	return c.store.Set(ctx, key, value, o)
+}
+
+func (c *Client) SetMany(ctx context.Context, items []goku.SetItem) error {
+	return c.store.SetMany(ctx, items)
+}
+
+func (c *Client) Create(ctx context.Context, prefix string, value []byte, opts ...goku.SetOption) (string, error) {
+	var o goku.SetOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return c.store.Create(ctx, prefix, value, o)
+}
+
+func (c *Client) Increment(ctx context.Context, key string, delta int64) (int64, error) {
+	return c.store.Increment(ctx, key, delta)
 }
 
-func (c *Client) Delete(ctx context.Context, key string) error {
-	return db.Delete(ctx, c.wdbc, key)
+func (c *Client) Delete(ctx context.Context, key string, opts ...goku.DeleteOption) error {
+	var o goku.DeleteOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return c.store.Delete(ctx, key, o)
+}
+
+func (c *Client) DeletePrefix(ctx context.Context, prefix string, opts ...goku.DeletePrefixOption) (int64, error) {
+	var o goku.DeletePrefixOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return c.store.DeletePrefix(ctx, prefix, o)
 }
 
 func (c *Client) Get(ctx context.Context, key string) (goku.KV, error) {
-	return db.Get(ctx, c.rdbc, key)
+	return c.store.Get(ctx, key)
+}
+
+func (c *Client) GetMany(ctx context.Context, keys []string) ([]goku.KV, error) {
+	return c.store.GetMany(ctx, keys)
 }
 
-func (c *Client) List(ctx context.Context, prefix string) ([]goku.KV, error) {
+func (c *Client) List(ctx context.Context, prefix string, opts ...goku.ListOption) ([]goku.KV, error) {
+	var o goku.ListOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	var res []goku.KV
 	fn := func(kv goku.KV) error {
 		res = append(res, kv)
 		return nil
 	}
 
-	err := db.List(ctx, c.rdbc, prefix, fn)
+	err := c.store.List(ctx, prefix, o, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	o.SetCursor(res)
+
+	return res, nil
+}
+
+func (c *Client) ListSnapshot(ctx context.Context, prefix string) (goku.Snapshot, error) {
+	return c.store.ListSnapshot(ctx, prefix)
+}
+
+func (c *Client) GetAt(ctx context.Context, key string, ref int64) (goku.KV, error) {
+	return c.store.GetAt(ctx, key, ref)
+}
+
+func (c *Client) ListAt(ctx context.Context, prefix string, ref int64) ([]goku.KV, error) {
+	var res []goku.KV
+	fn := func(kv goku.KV) error {
+		res = append(res, kv)
+		return nil
+	}
+
+	err := c.store.ListAt(ctx, prefix, ref, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *Client) History(ctx context.Context, key string, opts ...goku.HistoryOption) ([]goku.Revision, error) {
+	var o goku.HistoryOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var res []goku.Revision
+	fn := func(r goku.Revision) error {
+		res = append(res, r)
+		return nil
+	}
+
+	err := c.store.History(ctx, key, o, fn)
 	if err != nil {
 		return nil, err
 	}
@@ -68,39 +137,53 @@ func (c *Client) List(ctx context.Context, prefix string) ([]goku.KV, error) {
 }
 
 func (c *Client) UpdateLease(ctx context.Context, leaseID int64, expiresAt time.Time) error {
-	return db.UpdateLease(ctx, c.wdbc, leaseID, expiresAt)
+	return c.store.UpdateLease(ctx, leaseID, expiresAt)
 }
 
 func (c *Client) ExpireLease(ctx context.Context, leaseID int64) error {
-	return db.ExpireLease(ctx, c.wdbc, leaseID)
+	return c.store.ExpireLease(ctx, leaseID)
 }
 
-func (c *Client) Stream(prefix string) reflex.StreamFunc {
-	return func(ctx context.Context, after string, opts ...reflex.StreamOption) (reflex.StreamClient, error) {
-		cl, err := db.ToStream(c.rdbc)(ctx, after, opts...)
-		if err != nil {
-			return nil, err
-		}
-
-		return &prefixFilter{
-			prefix: prefix,
-			cl:     cl,
-		}, nil
-	}
+func (c *Client) GrantLease(ctx context.Context, expiresAt time.Time) (int64, error) {
+	return c.store.GrantLease(ctx, expiresAt)
 }
 
-type prefixFilter struct {
-	prefix string
-	cl     reflex.StreamClient
+func (c *Client) GetLease(ctx context.Context, leaseID int64) (goku.Lease, error) {
+	return c.store.GetLease(ctx, leaseID)
 }
 
-func (f *prefixFilter) Recv() (*reflex.Event, error) {
-	for {
-		e, err := f.cl.Recv()
-		if err != nil {
-			return nil, err
-		} else if strings.HasPrefix(e.ForeignID, f.prefix) {
-			return e, nil
-		}
+func (c *Client) ListLeaseKeys(ctx context.Context, leaseID int64) ([]goku.KV, error) {
+	var res []goku.KV
+	fn := func(kv goku.KV) error {
+		res = append(res, kv)
+		return nil
+	}
+
+	err := c.store.ListLeaseKeys(ctx, leaseID, fn)
+	if err != nil {
+		return nil, err
 	}
+
+	return res, nil
+}
+
+func (c *Client) ListLeases(ctx context.Context, opts ...goku.ListLeasesOption) ([]goku.Lease, error) {
+	var o goku.ListLeasesOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return c.store.ListLeases(ctx, o)
+}
+
+func (c *Client) Txn(ctx context.Context, compares []goku.Compare, then []goku.Op, els []goku.Op) (goku.TxnResponse, error) {
+	return c.store.Txn(ctx, compares, then, els)
+}
+
+func (c *Client) Stream(prefix string) reflex.StreamFunc {
+	return c.store.Stream(prefix)
+}
+
+func (c *Client) WatchStream(prefix string) goku.WatchStreamFunc {
+	return c.store.WatchStream(prefix)
 }
@@ -0,0 +1,19 @@
+package logical_test
+
+import (
+	"testing"
+
+	"github.com/corverroos/goku"
+	"github.com/corverroos/goku/client/logical"
+	"github.com/corverroos/goku/clienttest"
+	"github.com/corverroos/goku/db"
+)
+
+func TestSuite(t *testing.T) {
+	clienttest.RunSuite(t, func(t *testing.T) goku.Client {
+		db.CleanCache(t)
+		dbc := db.ConnectForTesting(t)
+		dbc.SetMaxOpenConns(100)
+		return logical.New(db.NewStore(dbc, dbc))
+	})
+}
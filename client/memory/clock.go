@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a manually controlled clock. Advancing the clock expires
+// the due leases of all clients using it.
+type Clock struct {
+	mu   sync.Mutex
+	now  time.Time
+	subs []func()
+}
+
+// NewClock returns a new clock set to the provided time.
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// Now returns the current time of the clock.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Set sets the clock to the provided time.
+func (c *Clock) Set(t time.Time) {
+	c.mu.Lock()
+	c.now = t
+	subs := append([]func(){}, c.subs...)
+	c.mu.Unlock()
+
+	for _, fn := range subs {
+		fn()
+	}
+}
+
+// Add advances the clock by the duration.
+func (c *Clock) Add(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// subscribe registers fn to be called each time the clock changes.
+func (c *Clock) subscribe(fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.subs = append(c.subs, fn)
+}
@@ -0,0 +1,194 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/errors"
+)
+
+func (c *Client) UpdateLease(ctx context.Context, leaseID int64, expiresAt time.Time) error {
+	return c.write(func() error {
+		return c.updateLeaseLocked(leaseID, expiresAt)
+	})
+}
+
+func (c *Client) ExpireLease(ctx context.Context, leaseID int64) error {
+	return c.write(func() error {
+		return c.expireLeaseLocked(leaseID)
+	})
+}
+
+func (c *Client) GrantLease(ctx context.Context, expiresAt time.Time) (int64, error) {
+	var id int64
+	err := c.write(func() error {
+		id = c.insertLease(expiresAt)
+		return nil
+	})
+
+	return id, err
+}
+
+func (c *Client) GetLease(ctx context.Context, leaseID int64) (goku.Lease, error) {
+	var res goku.Lease
+	err := c.read(func() error {
+		l, ok := c.s.leases[leaseID]
+		if !ok {
+			return errors.Wrap(goku.ErrLeaseNotFound, "")
+		}
+
+		res = l
+		return nil
+	})
+
+	return res, err
+}
+
+func (c *Client) ListLeaseKeys(ctx context.Context, leaseID int64) ([]goku.KV, error) {
+	var res []goku.KV
+	err := c.read(func() error {
+		res = c.leaseKeysLocked(leaseID)
+		return nil
+	})
+
+	return res, err
+}
+
+func (c *Client) ListLeases(ctx context.Context, opts ...goku.ListLeasesOption) ([]goku.Lease, error) {
+	var o goku.ListLeasesOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var res []goku.Lease
+	err := c.read(func() error {
+		for _, l := range c.sortedLeasesLocked() {
+			if l.ID <= o.AfterID {
+				continue
+			} else if !o.IncludeExpired && l.Expired {
+				continue
+			} else if !o.ExpiresBefore.IsZero() && (l.ExpiresAt.IsZero() || !l.ExpiresAt.Before(o.ExpiresBefore)) {
+				continue
+			} else if o.Limit > 0 && int64(len(res)) == o.Limit {
+				break
+			}
+
+			res = append(res, l)
+		}
+
+		return nil
+	})
+
+	return res, err
+}
+
+// insertLease inserts a new lease and returns its id.
+func (c *Client) insertLease(expiresAt time.Time) int64 {
+	c.s.leaseSeq++
+
+	c.s.leases[c.s.leaseSeq] = goku.Lease{
+		ID:        c.s.leaseSeq,
+		ExpiresAt: toDBTime(expiresAt),
+		Version:   1,
+	}
+
+	return c.s.leaseSeq
+}
+
+// updateLeaseLocked updates the expiry of the (non-expired) lease.
+func (c *Client) updateLeaseLocked(leaseID int64, expiresAt time.Time) error {
+	l, ok := c.s.leases[leaseID]
+	if !ok || l.Expired {
+		return errors.Wrap(goku.ErrLeaseNotFound, "")
+	}
+
+	l.Version++
+	l.ExpiresAt = toDBTime(expiresAt)
+	c.s.leases[leaseID] = l
+
+	return nil
+}
+
+// touchLeaseLocked increments the version of the (non-expired) lease without changing its expiry.
+func (c *Client) touchLeaseLocked(leaseID int64) error {
+	l, ok := c.s.leases[leaseID]
+	if !ok || l.Expired {
+		return errors.Wrap(goku.ErrLeaseNotFound, "")
+	}
+
+	l.Version++
+	c.s.leases[leaseID] = l
+
+	return nil
+}
+
+// expireLeaseLocked expires the (non-expired) lease and deletes all its key-values
+// with expire events like db.ExpireLease.
+func (c *Client) expireLeaseLocked(leaseID int64) error {
+	l, ok := c.s.leases[leaseID]
+	if !ok || l.Expired {
+		return errors.Wrap(goku.ErrLeaseNotFound, "")
+	}
+
+	l.Version++
+	l.ExpiresAt = time.Time{}
+	l.Expired = true
+	c.s.leases[leaseID] = l
+
+	for _, kv := range c.leaseKeysLocked(leaseID) {
+		c.deleteKV(kv, goku.EventTypeExpire)
+	}
+
+	return nil
+}
+
+// expireLeases expires all leases with expires_at before or at the current time in order of expiry.
+func (c *Client) expireLeases() {
+	now := c.now()
+
+	var due []goku.Lease
+	for _, l := range c.sortedLeasesLocked() {
+		if l.Expired || l.ExpiresAt.IsZero() || l.ExpiresAt.After(now) {
+			continue
+		}
+		due = append(due, l)
+	}
+
+	if len(due) == 0 {
+		return
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].ExpiresAt.Before(due[j].ExpiresAt)
+	})
+
+	for _, l := range due {
+		// Due leases are not expired, so this cannot fail.
+		_ = c.expireLeaseLocked(l.ID)
+	}
+
+	c.broadcast()
+}
+
+// leaseKeysLocked returns the (non-deleted) key-values associated with the lease ordered by key.
+func (c *Client) leaseKeysLocked(leaseID int64) []goku.KV {
+	return c.filterLocked(func(kv goku.KV) bool {
+		return kv.LeaseID == leaseID
+	})
+}
+
+// sortedLeasesLocked returns all leases ordered by id.
+func (c *Client) sortedLeasesLocked() []goku.Lease {
+	res := make([]goku.Lease, 0, len(c.s.leases))
+	for _, l := range c.s.leases {
+		res = append(res, l)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+
+	return res
+}
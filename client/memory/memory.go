@@ -0,0 +1,785 @@
+// Package memory provides an in-memory goku client for unit tests.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/reflex"
+)
+
+var _ goku.Client = (*Client)(nil)
+
+const defaultDeleteBatch = 1000
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithClock returns an option to use the provided clock for lease expiry and event timestamps
+// instead of the system clock. Leases are expired as soon as the clock is advanced past their expiry.
+func WithClock(clock *Clock) Option {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// New returns a new in-memory goku client.
+func New(opts ...Option) *Client {
+	c := &Client{
+		s: state{
+			data:   make(map[string]goku.KV),
+			leases: make(map[int64]goku.Lease),
+			seqs:   make(map[string]int64),
+		},
+		notify: make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.clock != nil {
+		c.clock.subscribe(func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+
+			c.expireLeases()
+			c.broadcast()
+		})
+	}
+
+	return c
+}
+
+// Client is an in-memory goku.Client mirroring the semantics of the mysql backed clients;
+// versions, refs, soft deletes, leases, conditional errors and event streams. It is intended for unit tests.
+//
+// Differences to the mysql backed clients:
+//   - Keys are ordered by bytes, not by mysql collation.
+//   - Leases are expired before each call and when the clock is advanced, not by a background process.
+//   - Operations are serialised, so ErrUpdateRace is only returned if injected via InjectUpdateRaces.
+//   - The event log is never compacted and ephemeral keys are not supported.
+type Client struct {
+	clock *Clock
+
+	mu     sync.Mutex
+	s      state
+	races  int
+	notify chan struct{}
+}
+
+// state is the transactional state of the client.
+type state struct {
+	data     map[string]goku.KV
+	leases   map[int64]goku.Lease
+	seqs     map[string]int64
+	events   []goku.WatchEvent
+	leaseSeq int64
+}
+
+// clone returns a copy of the state. Events are append-only, so they are not copied.
+func (s state) clone() state {
+	res := s
+	res.data = make(map[string]goku.KV, len(s.data))
+	for k, v := range s.data {
+		res.data[k] = v
+	}
+	res.leases = make(map[int64]goku.Lease, len(s.leases))
+	for k, v := range s.leases {
+		res.leases[k] = v
+	}
+	res.seqs = make(map[string]int64, len(s.seqs))
+	for k, v := range s.seqs {
+		res.seqs[k] = v
+	}
+	return res
+}
+
+// InjectUpdateRaces results in the next n write calls returning goku.ErrUpdateRace without any effect.
+// It can be used to test retry logic.
+func (c *Client) InjectUpdateRaces(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.races = n
+}
+
+// read calls fn with the client locked after expiring due leases.
+func (c *Client) read(fn func() error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expireLeases()
+
+	return fn()
+}
+
+// write calls fn as a transaction with the client locked after expiring due leases. The state is
+// restored if fn returns an error. Like auto increment ids, the ids of rolled back leases and events
+// are not reused; rolled back events are replaced by noop events.
+func (c *Client) write(fn func() error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expireLeases()
+
+	if c.races > 0 {
+		c.races--
+		return errors.Wrap(goku.ErrUpdateRace, "injected")
+	}
+
+	prev := c.s.clone()
+	n := len(c.s.events)
+
+	err := fn()
+	if err != nil {
+		events, leaseSeq := c.s.events, c.s.leaseSeq
+		for i := n; i < len(events); i++ {
+			events[i] = goku.WatchEvent{Ref: events[i].Ref, Timestamp: events[i].Timestamp}
+		}
+
+		c.s = prev
+		c.s.events = events
+		c.s.leaseSeq = leaseSeq
+
+		return err
+	}
+
+	if len(c.s.events) > n {
+		c.broadcast()
+	}
+
+	return nil
+}
+
+// broadcast notifies all waiting streams.
+func (c *Client) broadcast() {
+	close(c.notify)
+	c.notify = make(chan struct{})
+}
+
+func (c *Client) now() time.Time {
+	if c.clock != nil {
+		return c.clock.Now()
+	}
+
+	return time.Now()
+}
+
+func (c *Client) Set(ctx context.Context, key string, value []byte, opts ...goku.SetOption) error {
+	var o goku.SetOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return c.write(func() error {
+		return c.setLocked(key, value, o)
+	})
+}
+
+func (c *Client) SetMany(ctx context.Context, items []goku.SetItem) error {
+	return c.write(func() error {
+		for _, item := range items {
+			err := c.setLocked(item.Key, item.Value, item.SetOptions)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (c *Client) Create(ctx context.Context, prefix string, value []byte, opts ...goku.SetOption) (string, error) {
+	var o goku.SetOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var key string
+	err := c.write(func() error {
+		seq := c.s.seqs[prefix] + 1
+		c.s.seqs[prefix] = seq
+
+		key = prefix + fmt.Sprintf("%020d", seq)
+
+		return c.setLocked(key, value, goku.SetOptions{
+			LeaseID:    o.LeaseID,
+			ExpiresAt:  o.ExpiresAt,
+			CreateOnly: true,
+			Ephemeral:  o.Ephemeral,
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+func (c *Client) Increment(ctx context.Context, key string, delta int64) (int64, error) {
+	var next int64
+	err := c.write(func() error {
+		var (
+			val  int64
+			prev goku.SetOptions
+		)
+
+		kv, ok := c.s.data[key]
+		if ok && kv.DeletedRef == 0 {
+			if len(kv.Value) > 0 {
+				var err error
+				val, err = strconv.ParseInt(string(kv.Value), 10, 64)
+				if err != nil {
+					return errors.Wrap(goku.ErrNotInteger, "", j.KV("key", key))
+				}
+			}
+
+			l, ok := c.s.leases[kv.LeaseID]
+			if !ok {
+				return errors.Wrap(goku.ErrLeaseNotFound, "")
+			}
+
+			prev.LeaseID = l.ID
+			prev.ExpiresAt = l.ExpiresAt
+			prev.PrevVersion = kv.Version
+		}
+
+		next = val + delta
+		if (delta > 0 && next < val) || (delta < 0 && next > val) {
+			return errors.New("increment overflow", j.KV("key", key))
+		}
+
+		return c.setLocked(key, []byte(strconv.FormatInt(next, 10)), prev)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return next, nil
+}
+
+func (c *Client) Delete(ctx context.Context, key string, opts ...goku.DeleteOption) error {
+	var o goku.DeleteOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var prev goku.KV
+	err := c.write(func() error {
+		var err error
+		prev, err = c.deleteLocked(key, o)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if o.Prev != nil {
+		*o.Prev = prev
+	}
+
+	return nil
+}
+
+func (c *Client) DeletePrefix(ctx context.Context, prefix string, opts ...goku.DeletePrefixOption) (int64, error) {
+	var o goku.DeletePrefixOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if prefix == "" {
+		return 0, errors.Wrap(goku.ErrInvalidKey, "empty prefix")
+	}
+
+	batch := o.BatchSize
+	if batch <= 0 {
+		batch = defaultDeleteBatch
+	}
+
+	// Delete in batches, each in its own transaction.
+	var total int64
+	for {
+		var n int64
+		err := c.write(func() error {
+			for _, kv := range c.listLocked(prefix) {
+				if n == batch {
+					break
+				}
+
+				_, err := c.deleteLocked(kv.Key, goku.DeleteOptions{})
+				if err != nil {
+					return err
+				}
+				n++
+			}
+
+			return nil
+		})
+		if err != nil {
+			return total, err
+		}
+
+		total += n
+
+		if n < batch {
+			return total, nil
+		}
+	}
+}
+
+func (c *Client) Get(ctx context.Context, key string) (goku.KV, error) {
+	var res goku.KV
+	err := c.read(func() error {
+		kv, ok := c.s.data[key]
+		if !ok || kv.DeletedRef != 0 {
+			return errors.Wrap(goku.ErrNotFound, "")
+		}
+
+		res = copyKV(kv)
+		return nil
+	})
+
+	return res, err
+}
+
+func (c *Client) GetMany(ctx context.Context, keys []string) ([]goku.KV, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	var res []goku.KV
+	err := c.read(func() error {
+		for _, key := range keys {
+			kv, ok := c.s.data[key]
+			if !ok || kv.DeletedRef != 0 {
+				kv = goku.KV{}
+			}
+			res = append(res, copyKV(kv))
+		}
+
+		return nil
+	})
+
+	return res, err
+}
+
+func (c *Client) List(ctx context.Context, prefix string, opts ...goku.ListOption) ([]goku.KV, error) {
+	var o goku.ListOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var res []goku.KV
+	err := c.read(func() error {
+		kvs := c.listLocked(prefix)
+		if o.Reverse {
+			for i, j := 0, len(kvs)-1; i < j; i, j = i+1, j-1 {
+				kvs[i], kvs[j] = kvs[j], kvs[i]
+			}
+		}
+
+		// before returns true if key a is before key b in the list order.
+		before := func(a, b string) bool {
+			if o.Reverse {
+				return a > b
+			}
+			return a < b
+		}
+
+		for _, kv := range kvs {
+			if o.StartAfter != "" && !before(o.StartAfter, kv.Key) {
+				continue
+			} else if o.EndKey != "" && !before(kv.Key, o.EndKey) {
+				continue
+			} else if o.Limit > 0 && int64(len(res)) == o.Limit {
+				break
+			}
+
+			if o.KeysOnly {
+				kv.Value = nil
+			}
+
+			res = append(res, kv)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	o.SetCursor(res)
+
+	return res, nil
+}
+
+func (c *Client) ListSnapshot(ctx context.Context, prefix string) (goku.Snapshot, error) {
+	var res goku.Snapshot
+	err := c.read(func() error {
+		res.KVs = c.listLocked(prefix)
+		res.Ref = int64(len(c.s.events))
+		return nil
+	})
+
+	return res, err
+}
+
+func (c *Client) GetAt(ctx context.Context, key string, ref int64) (goku.KV, error) {
+	var res goku.KV
+	err := c.read(func() error {
+		kv := c.foldLocked(ref, func(k string) bool { return k == key })[key]
+		if kv.Version == 0 || kv.DeletedRef != 0 {
+			return errors.Wrap(goku.ErrNotFound, "")
+		}
+
+		res = kv
+		return nil
+	})
+
+	return res, err
+}
+
+func (c *Client) ListAt(ctx context.Context, prefix string, ref int64) ([]goku.KV, error) {
+	var res []goku.KV
+	err := c.read(func() error {
+		kvs := c.foldLocked(ref, func(k string) bool { return strings.HasPrefix(k, prefix) })
+		for _, kv := range kvs {
+			if kv.DeletedRef != 0 {
+				continue
+			}
+			res = append(res, kv)
+		}
+
+		sort.Slice(res, func(i, j int) bool {
+			return res[i].Key < res[j].Key
+		})
+
+		return nil
+	})
+
+	return res, err
+}
+
+func (c *Client) History(ctx context.Context, key string, opts ...goku.HistoryOption) ([]goku.Revision, error) {
+	var o goku.HistoryOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var res []goku.Revision
+	err := c.read(func() error {
+		events := c.s.events
+		for i := range events {
+			e := events[i]
+			if o.Reverse {
+				e = events[len(events)-1-i]
+			}
+
+			if e.Type == goku.EventTypeUnknown || e.Key != key {
+				continue
+			} else if o.AfterRef > 0 && o.Reverse && e.Ref >= o.AfterRef {
+				continue
+			} else if o.AfterRef > 0 && !o.Reverse && e.Ref <= o.AfterRef {
+				continue
+			} else if o.Limit > 0 && int64(len(res)) == o.Limit {
+				break
+			}
+
+			res = append(res, goku.Revision{
+				Key:       e.Key,
+				Type:      e.Type,
+				Ref:       e.Ref,
+				Timestamp: e.Timestamp,
+				Value:     copyBytes(e.Value),
+			})
+		}
+
+		return nil
+	})
+
+	return res, err
+}
+
+func (c *Client) Txn(ctx context.Context, compares []goku.Compare, then []goku.Op, els []goku.Op) (goku.TxnResponse, error) {
+	var resp goku.TxnResponse
+	err := c.write(func() error {
+		resp = goku.TxnResponse{Succeeded: true}
+		for _, cmp := range compares {
+			ok, err := c.compareLocked(cmp)
+			if err != nil {
+				return err
+			} else if !ok {
+				resp.Succeeded = false
+			}
+		}
+
+		ops := then
+		if !resp.Succeeded {
+			ops = els
+		}
+
+		for _, op := range ops {
+			switch op.Type {
+			case goku.OpTypeGet:
+				kv, ok := c.s.data[op.Key]
+				if !ok || kv.DeletedRef != 0 {
+					kv = goku.KV{}
+				}
+				resp.Gets = append(resp.Gets, copyKV(kv))
+			case goku.OpTypeSet:
+				err := c.setLocked(op.Key, op.Value, op.SetOptions)
+				if err != nil {
+					return err
+				}
+			case goku.OpTypeDelete:
+				_, err := c.deleteLocked(op.Key, op.DeleteOptions)
+				if err != nil {
+					return err
+				}
+			default:
+				return errors.New("invalid op type", j.KV("type", op.Type))
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return goku.TxnResponse{}, err
+	}
+
+	return resp, nil
+}
+
+func (c *Client) Stream(prefix string) reflex.StreamFunc {
+	return func(ctx context.Context, after string, opts ...reflex.StreamOption) (reflex.StreamClient, error) {
+		s, err := c.newStream(ctx, prefix, after, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return &reflexStream{s: s}, nil
+	}
+}
+
+func (c *Client) WatchStream(prefix string) goku.WatchStreamFunc {
+	return func(ctx context.Context, after string, opts ...reflex.StreamOption) (goku.WatchStreamClient, error) {
+		return c.newStream(ctx, prefix, after, opts)
+	}
+}
+
+// setLocked creates or updates the key-value like db.Set.
+func (c *Client) setLocked(key string, value []byte, o goku.SetOptions) error {
+	if o.Ephemeral {
+		return goku.ErrEphemeralUnsupported
+	} else if len(key) == 0 || len(key) >= 256 || strings.Contains(key, "%") {
+		return goku.ErrInvalidKey
+	}
+
+	var leaseID, createRef int64
+
+	kv, ok := c.s.data[key]
+	if ok && kv.DeletedRef == 0 {
+		leaseID = kv.LeaseID
+		createRef = kv.CreatedRef
+	}
+
+	if o.CreateOnly && kv.Version > 0 && kv.DeletedRef == 0 {
+		return errors.Wrap(goku.ErrConditional, "key already created")
+	} else if o.PrevVersion > 0 && kv.Version != o.PrevVersion {
+		return errors.Wrap(goku.ErrConditional, "previous version mismatch")
+	}
+
+	// Maybe override with requested lease.
+	if o.LeaseID != 0 {
+		leaseID = o.LeaseID
+	}
+
+	if leaseID == 0 {
+		leaseID = c.insertLease(o.ExpiresAt)
+	} else if o.LeaseID != 0 && o.ExpiresAt.IsZero() {
+		// Leave the expiry of the requested lease as is.
+		err := c.touchLeaseLocked(leaseID)
+		if err != nil {
+			return err
+		}
+	} else {
+		err := c.updateLeaseLocked(leaseID, o.ExpiresAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	value = copyBytes(value)
+	ref := c.insertEvent(key, goku.EventTypeSet, value, kv.Version+1, leaseID)
+
+	if createRef == 0 {
+		createRef = ref
+	}
+
+	c.s.data[key] = goku.KV{
+		Key:        key,
+		Value:      value,
+		Version:    kv.Version + 1,
+		CreatedRef: createRef,
+		UpdatedRef: ref,
+		LeaseID:    leaseID,
+	}
+
+	return nil
+}
+
+// deleteLocked soft-deletes the key-value like db.Delete.
+func (c *Client) deleteLocked(key string, o goku.DeleteOptions) (goku.KV, error) {
+	kv, ok := c.s.data[key]
+	if !ok || kv.DeletedRef != 0 {
+		return goku.KV{}, errors.Wrap(goku.ErrNotFound, "")
+	} else if o.PrevVersion > 0 && kv.Version != o.PrevVersion {
+		return goku.KV{}, errors.Wrap(goku.ErrConditional, "previous version mismatch")
+	} else if o.LeaseID > 0 && kv.LeaseID != o.LeaseID {
+		return goku.KV{}, errors.Wrap(goku.ErrConditional, "lease mismatch")
+	}
+
+	c.deleteKV(kv, goku.EventTypeDelete)
+
+	return kv, nil
+}
+
+// deleteKV soft-deletes the key-value inserting an event of the type.
+func (c *Client) deleteKV(kv goku.KV, typ goku.EventType) {
+	ref := c.insertEvent(kv.Key, typ, nil, kv.Version+1, kv.LeaseID)
+
+	kv.Value = nil
+	kv.Version++
+	kv.UpdatedRef = ref
+	kv.DeletedRef = ref
+	c.s.data[kv.Key] = kv
+}
+
+// compareLocked returns true if the compare predicate is true for the current state of the key like db.Txn.
+func (c *Client) compareLocked(cmp goku.Compare) (bool, error) {
+	kv, ok := c.s.data[cmp.Key]
+	if !ok || kv.DeletedRef != 0 {
+		kv = goku.KV{}
+	}
+
+	switch cmp.Type {
+	case goku.CompareTypeVersion:
+		return kv.Version == cmp.Value, nil
+	case goku.CompareTypeCreatedRef:
+		return kv.CreatedRef == cmp.Value, nil
+	case goku.CompareTypeExists:
+		return (kv.Version > 0) == (cmp.Value != 0), nil
+	case goku.CompareTypeLeaseID:
+		return kv.LeaseID == cmp.Value, nil
+	default:
+		return false, errors.New("invalid compare type", j.KV("type", cmp.Type))
+	}
+}
+
+// listLocked returns all the (non-deleted) key-values with keys matching the prefix ordered by key.
+func (c *Client) listLocked(prefix string) []goku.KV {
+	return c.filterLocked(func(kv goku.KV) bool {
+		return strings.HasPrefix(kv.Key, prefix)
+	})
+}
+
+// filterLocked returns copies of all the (non-deleted) key-values matching the predicate ordered by key.
+func (c *Client) filterLocked(fn func(goku.KV) bool) []goku.KV {
+	var res []goku.KV
+	for _, kv := range c.s.data {
+		if kv.DeletedRef != 0 || !fn(kv) {
+			continue
+		}
+		res = append(res, copyKV(kv))
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Key < res[j].Key
+	})
+
+	return res
+}
+
+// foldLocked returns the key-values of the matching keys as they were after the event with id ref. Like
+// db.GetAt, key-values are reconstructed from the events, so LeaseID is not populated.
+func (c *Client) foldLocked(ref int64, match func(key string) bool) map[string]goku.KV {
+	res := make(map[string]goku.KV)
+	for _, e := range c.s.events {
+		if e.Ref > ref {
+			break
+		} else if e.Type == goku.EventTypeUnknown || !match(e.Key) {
+			continue
+		}
+
+		kv := res[e.Key]
+		kv.Key = e.Key
+		kv.Version++
+		kv.UpdatedRef = e.Ref
+
+		switch e.Type {
+		case goku.EventTypeSet:
+			if kv.CreatedRef == 0 || kv.DeletedRef != 0 {
+				kv.CreatedRef = e.Ref
+			}
+			kv.DeletedRef = 0
+			kv.Value = copyBytes(e.Value)
+		case goku.EventTypeDelete, goku.EventTypeExpire:
+			kv.DeletedRef = e.Ref
+			kv.Value = nil
+		}
+
+		res[e.Key] = kv
+	}
+
+	return res
+}
+
+// insertEvent appends an event and returns its id.
+func (c *Client) insertEvent(key string, typ goku.EventType, value []byte, version, leaseID int64) int64 {
+	ref := int64(len(c.s.events)) + 1
+
+	c.s.events = append(c.s.events, goku.WatchEvent{
+		Key:       key,
+		Type:      typ,
+		Value:     value,
+		Version:   version,
+		LeaseID:   leaseID,
+		Ref:       ref,
+		Timestamp: toDBTime(c.now()),
+	})
+
+	return ref
+}
+
+// toDBTime returns the time as stored and returned by mysql datetime(3) columns.
+func toDBTime(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Time{}
+	}
+
+	return t.Round(time.Millisecond).UTC()
+}
+
+// copyKV returns a copy of the key-value not sharing its value with the internal state.
+func copyKV(kv goku.KV) goku.KV {
+	kv.Value = copyBytes(kv.Value)
+	return kv
+}
+
+// copyBytes returns a copy of b, preserving nil.
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+
+	return append([]byte{}, b...)
+}
@@ -0,0 +1,338 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/corverroos/goku/client/memory"
+	"github.com/corverroos/goku/clienttest"
+	"github.com/luno/jettison/jtest"
+	"github.com/luno/reflex"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuite(t *testing.T) {
+	clienttest.RunSuite(t, func(t *testing.T) goku.Client {
+		return memory.New()
+	})
+}
+
+func TestSetGetDelete(t *testing.T) {
+	ctx := context.Background()
+	cl := memory.New()
+
+	_, err := cl.Get(ctx, "key")
+	jtest.Require(t, goku.ErrNotFound, err)
+
+	err = cl.Set(ctx, "key", []byte("1"))
+	jtest.RequireNil(t, err)
+
+	err = cl.Set(ctx, "key", []byte("2"))
+	jtest.RequireNil(t, err)
+
+	kv, err := cl.Get(ctx, "key")
+	jtest.RequireNil(t, err)
+	require.Equal(t, goku.KV{
+		Key:        "key",
+		Value:      []byte("2"),
+		Version:    2,
+		CreatedRef: 1,
+		UpdatedRef: 2,
+		LeaseID:    1,
+	}, kv)
+
+	err = cl.Set(ctx, "key", nil, goku.WithPrevVersion(1))
+	jtest.Require(t, goku.ErrConditional, err)
+
+	err = cl.Set(ctx, "key", nil, goku.WithCreateOnly())
+	jtest.Require(t, goku.ErrConditional, err)
+
+	err = cl.Set(ctx, "inv%lid", nil)
+	jtest.Require(t, goku.ErrInvalidKey, err)
+
+	err = cl.Delete(ctx, "key")
+	jtest.RequireNil(t, err)
+
+	_, err = cl.Get(ctx, "key")
+	jtest.Require(t, goku.ErrNotFound, err)
+
+	err = cl.Delete(ctx, "key")
+	jtest.Require(t, goku.ErrNotFound, err)
+
+	// Recreated key-values get a new created ref and lease.
+	err = cl.Set(ctx, "key", nil)
+	jtest.RequireNil(t, err)
+
+	kv, err = cl.Get(ctx, "key")
+	jtest.RequireNil(t, err)
+	require.Equal(t, goku.KV{
+		Key:        "key",
+		Version:    4,
+		CreatedRef: 4,
+		UpdatedRef: 4,
+		LeaseID:    2,
+	}, kv)
+
+	kv, err = cl.GetAt(ctx, "key", 2)
+	jtest.RequireNil(t, err)
+	require.Equal(t, []byte("2"), kv.Value)
+
+	_, err = cl.GetAt(ctx, "key", 3)
+	jtest.Require(t, goku.ErrNotFound, err)
+
+	rl, err := cl.History(ctx, "key", goku.WithHistoryReverse(), goku.WithHistoryLimit(2))
+	jtest.RequireNil(t, err)
+	require.Len(t, rl, 2)
+	require.Equal(t, goku.EventTypeSet, rl[0].Type)
+	require.Equal(t, goku.EventTypeDelete, rl[1].Type)
+}
+
+func TestList(t *testing.T) {
+	ctx := context.Background()
+	cl := memory.New()
+
+	for _, key := range []string{"a/1", "a/2", "a/3", "b/1"} {
+		err := cl.Set(ctx, key, []byte(key))
+		jtest.RequireNil(t, err)
+	}
+
+	assertKeys := func(t *testing.T, kvs []goku.KV, keys ...string) {
+		t.Helper()
+		var res []string
+		for _, kv := range kvs {
+			res = append(res, kv.Key)
+		}
+		require.Equal(t, keys, res)
+	}
+
+	kvs, err := cl.List(ctx, "a/")
+	jtest.RequireNil(t, err)
+	assertKeys(t, kvs, "a/1", "a/2", "a/3")
+
+	kvs, err = cl.List(ctx, "a/", goku.WithStartAfter("a/1"), goku.WithLimit(1))
+	jtest.RequireNil(t, err)
+	assertKeys(t, kvs, "a/2")
+
+	kvs, err = cl.List(ctx, "", goku.WithReverse(), goku.WithEndKey("a/1"), goku.WithKeysOnly())
+	jtest.RequireNil(t, err)
+	assertKeys(t, kvs, "b/1", "a/3", "a/2")
+	require.Nil(t, kvs[0].Value)
+
+	n, err := cl.DeletePrefix(ctx, "a/", goku.WithDeleteBatchSize(2))
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(3), n)
+
+	kvs, err = cl.ListAt(ctx, "a/", 4)
+	jtest.RequireNil(t, err)
+	assertKeys(t, kvs, "a/1", "a/2", "a/3")
+
+	snap, err := cl.ListSnapshot(ctx, "")
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(7), snap.Ref)
+	assertKeys(t, snap.KVs, "b/1")
+}
+
+func TestValueCopies(t *testing.T) {
+	ctx := context.Background()
+	cl := memory.New()
+
+	err := cl.Set(ctx, "key", []byte("value"))
+	jtest.RequireNil(t, err)
+
+	assertValue := func(t *testing.T) {
+		t.Helper()
+
+		kv, err := cl.Get(ctx, "key")
+		jtest.RequireNil(t, err)
+		require.Equal(t, []byte("value"), kv.Value)
+	}
+
+	// Mutating returned values doesn't affect the stored value.
+	kv, err := cl.Get(ctx, "key")
+	jtest.RequireNil(t, err)
+	kv.Value[0] = 'x'
+	assertValue(t)
+
+	kvs, err := cl.GetMany(ctx, []string{"key"})
+	jtest.RequireNil(t, err)
+	kvs[0].Value[0] = 'x'
+	assertValue(t)
+
+	kvs, err = cl.List(ctx, "")
+	jtest.RequireNil(t, err)
+	kvs[0].Value[0] = 'x'
+	assertValue(t)
+
+	snap, err := cl.ListSnapshot(ctx, "")
+	jtest.RequireNil(t, err)
+	snap.KVs[0].Value[0] = 'x'
+	assertValue(t)
+
+	kv, err = cl.GetAt(ctx, "key", snap.Ref)
+	jtest.RequireNil(t, err)
+	kv.Value[0] = 'x'
+
+	kv, err = cl.GetAt(ctx, "key", snap.Ref)
+	jtest.RequireNil(t, err)
+	require.Equal(t, []byte("value"), kv.Value)
+
+	revs, err := cl.History(ctx, "key")
+	jtest.RequireNil(t, err)
+	revs[0].Value[0] = 'x'
+
+	revs, err = cl.History(ctx, "key")
+	jtest.RequireNil(t, err)
+	require.Equal(t, []byte("value"), revs[0].Value)
+
+	resp, err := cl.Txn(ctx, nil, []goku.Op{goku.OpGet("key")}, nil)
+	jtest.RequireNil(t, err)
+	resp.Gets[0].Value[0] = 'x'
+	assertValue(t)
+}
+
+func TestLeaseExpiry(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := memory.NewClock(t0)
+	cl := memory.New(memory.WithClock(clock))
+
+	id, err := cl.GrantLease(ctx, t0.Add(time.Minute))
+	jtest.RequireNil(t, err)
+
+	for _, key := range []string{"key1", "key2"} {
+		err := cl.Set(ctx, key, nil, goku.WithLeaseID(id), goku.WithExpiresAt(t0.Add(time.Minute)))
+		jtest.RequireNil(t, err)
+	}
+
+	sc, err := cl.Stream("")(ctx, "2")
+	jtest.RequireNil(t, err)
+
+	clock.Add(time.Minute)
+
+	for _, key := range []string{"key1", "key2"} {
+		e, err := sc.Recv()
+		jtest.RequireNil(t, err)
+		require.Equal(t, key, e.ForeignID)
+		require.True(t, reflex.IsType(e.Type, goku.EventTypeExpire))
+	}
+
+	l, err := cl.GetLease(ctx, id)
+	jtest.RequireNil(t, err)
+	require.True(t, l.Expired)
+
+	err = cl.UpdateLease(ctx, id, time.Time{})
+	jtest.Require(t, goku.ErrLeaseNotFound, err)
+
+	ll, err := cl.ListLeases(ctx, goku.WithIncludeExpired())
+	jtest.RequireNil(t, err)
+	require.Len(t, ll, 1)
+}
+
+func TestTxnRollback(t *testing.T) {
+	ctx := context.Background()
+	cl := memory.New()
+
+	err := cl.Set(ctx, "key1", nil)
+	jtest.RequireNil(t, err)
+
+	_, err = cl.Txn(ctx, nil, []goku.Op{
+		goku.OpSet("key2", nil),
+		goku.OpSet("key1", nil, goku.WithCreateOnly()),
+	}, nil)
+	jtest.Require(t, goku.ErrConditional, err)
+
+	_, err = cl.Get(ctx, "key2")
+	jtest.Require(t, goku.ErrNotFound, err)
+
+	// Rolled back ids are not reused.
+	err = cl.Set(ctx, "key3", nil)
+	jtest.RequireNil(t, err)
+
+	kv, err := cl.Get(ctx, "key3")
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(3), kv.CreatedRef)
+	require.Equal(t, int64(3), kv.LeaseID)
+
+	resp, err := cl.Txn(ctx, []goku.Compare{goku.CompareExists("key2", true)}, nil,
+		[]goku.Op{goku.OpGet("key1"), goku.OpGet("key2")})
+	jtest.RequireNil(t, err)
+	require.False(t, resp.Succeeded)
+	require.Equal(t, "key1", resp.Gets[0].Key)
+	require.Equal(t, goku.KV{}, resp.Gets[1])
+
+	cl.InjectUpdateRaces(1)
+
+	err = cl.Set(ctx, "key1", nil)
+	jtest.Require(t, goku.ErrUpdateRace, err)
+
+	err = cl.Set(ctx, "key1", nil)
+	jtest.RequireNil(t, err)
+}
+
+func TestCreateIncrement(t *testing.T) {
+	ctx := context.Background()
+	cl := memory.New()
+
+	key, err := cl.Create(ctx, "q/", []byte("value"))
+	jtest.RequireNil(t, err)
+	require.Equal(t, "q/00000000000000000001", key)
+
+	key2, err := cl.Create(ctx, "q/", nil)
+	jtest.RequireNil(t, err)
+	require.Equal(t, "q/00000000000000000002", key2)
+
+	val, err := cl.Increment(ctx, "counter", 5)
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(5), val)
+
+	val, err = cl.Increment(ctx, "counter", -7)
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(-2), val)
+
+	_, err = cl.Increment(ctx, key, 1)
+	jtest.Require(t, goku.ErrNotInteger, err)
+}
+
+func TestStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cl := memory.New()
+
+	err := cl.Set(ctx, "a/1", []byte("1"))
+	jtest.RequireNil(t, err)
+
+	err = cl.Set(ctx, "b/1", nil)
+	jtest.RequireNil(t, err)
+
+	sc, err := cl.WatchStream("a/")(ctx, "")
+	jtest.RequireNil(t, err)
+
+	e, err := sc.Recv()
+	jtest.RequireNil(t, err)
+	require.Equal(t, "a/1", e.Key)
+	require.Equal(t, []byte("1"), e.Value)
+	require.Equal(t, int64(1), e.LeaseID)
+
+	go func() {
+		time.Sleep(time.Millisecond * 10)
+		_ = cl.Set(ctx, "a/2", nil)
+	}()
+
+	// Blocks until the next event.
+	e, err = sc.Recv()
+	jtest.RequireNil(t, err)
+	require.Equal(t, "a/2", e.Key)
+	require.Equal(t, int64(3), e.Ref)
+
+	rsc, err := cl.Stream("")(ctx, "", reflex.WithStreamFromHead(), reflex.WithStreamToHead())
+	jtest.RequireNil(t, err)
+
+	_, err = rsc.Recv()
+	jtest.Require(t, reflex.ErrHeadReached, err)
+
+	cancel()
+	_, err = sc.Recv()
+	jtest.Require(t, context.Canceled, err)
+}
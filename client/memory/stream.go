@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/reflex"
+)
+
+// stream streams events with keys matching the prefix after the cursor.
+type stream struct {
+	c      *Client
+	ctx    context.Context
+	prefix string
+	cursor int64
+	opts   reflex.StreamOptions
+}
+
+func (c *Client) newStream(ctx context.Context, prefix string, after string,
+	opts []reflex.StreamOption) (*stream, error) {
+
+	var o reflex.StreamOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var cursor int64
+	if o.StreamFromHead {
+		c.mu.Lock()
+		cursor = int64(len(c.s.events))
+		c.mu.Unlock()
+	} else if after != "" {
+		var err error
+		cursor, err = strconv.ParseInt(after, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &stream{
+		c:      c,
+		ctx:    ctx,
+		prefix: prefix,
+		cursor: cursor,
+		opts:   o,
+	}, nil
+}
+
+// Recv blocks until the next event is found.
+func (s *stream) Recv() (goku.WatchEvent, error) {
+	for {
+		e, ok, delay, notify := s.next()
+		if ok {
+			return e, nil
+		} else if s.opts.StreamToHead {
+			return goku.WatchEvent{}, reflex.ErrHeadReached
+		}
+
+		var timer <-chan time.Time
+		if delay > 0 && s.c.clock == nil {
+			timer = time.After(delay)
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return goku.WatchEvent{}, s.ctx.Err()
+		case <-notify:
+		case <-timer:
+		}
+	}
+}
+
+// next returns the next event if available. Otherwise it returns the delay until the next
+// lagged event is available (if any) and a channel that is closed on subsequent events.
+func (s *stream) next() (goku.WatchEvent, bool, time.Duration, <-chan struct{}) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+
+	s.c.expireLeases()
+
+	events := s.c.s.events
+	for s.cursor < int64(len(events)) {
+		// Event ids start at 1, so the cursor is the index of the next event.
+		e := events[s.cursor]
+		if e.Type == goku.EventTypeUnknown || !strings.HasPrefix(e.Key, s.prefix) {
+			s.cursor++
+			continue
+		}
+
+		if s.opts.Lag > 0 {
+			delay := e.Timestamp.Add(s.opts.Lag).Sub(s.c.now())
+			if delay > 0 {
+				return goku.WatchEvent{}, false, delay, s.c.notify
+			}
+		}
+
+		s.cursor++
+		e.Value = copyBytes(e.Value)
+
+		return e, true, 0, nil
+	}
+
+	return goku.WatchEvent{}, false, 0, s.c.notify
+}
+
+// reflexStream adapts the stream to a reflex stream client.
+type reflexStream struct {
+	s *stream
+}
+
+func (r *reflexStream) Recv() (*reflex.Event, error) {
+	e, err := r.s.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	return &reflex.Event{
+		ID:        strconv.FormatInt(e.Ref, 10),
+		Type:      e.Type,
+		ForeignID: e.Key,
+		Timestamp: e.Timestamp,
+		MetaData:  e.Value,
+	}, nil
+}
@@ -0,0 +1,81 @@
+// Package clienttest provides a conformance suite that any goku.Client implementation
+// can run to prove it matches the goku contract.
+package clienttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/jtest"
+	"github.com/luno/reflex"
+	"github.com/stretchr/testify/require"
+)
+
+// Factory returns a new client backed by a new empty store. Refs and lease ids
+// are expected to start at 1. Use t.Cleanup to release any resources.
+type Factory func(t *testing.T) goku.Client
+
+// RunSuite runs the conformance suite as subtests, each with a new client from the factory.
+func RunSuite(t *testing.T, factory Factory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, cl goku.Client)
+	}{
+		{"EmptyNotFound", testEmptyNotFound},
+		{"InvalidKey", testInvalidKey},
+		{"Get", testGet},
+		{"GetSetMany", testGetSetMany},
+		{"List", testList},
+		{"ListOptions", testListOptions},
+		{"Create", testCreate},
+		{"ConcurrentCreate", testConcurrentCreate},
+		{"Increment", testIncrement},
+		{"ConcurrentIncrement", testConcurrentIncrement},
+		{"Update", testUpdate},
+		{"UpdateDelete", testUpdateDelete},
+		{"SetWithLease", testSetWithLease},
+		{"CreateOnly", testCreateOnly},
+		{"PrevVersion", testPrevVersion},
+		{"ConditionalDelete", testConditionalDelete},
+		{"DeletePrefix", testDeletePrefix},
+		{"Txn", testTxn},
+		{"ConcurrentTxnCreate", testConcurrentTxnCreate},
+		{"GetAtListAt", testGetAtListAt},
+		{"History", testHistory},
+		{"WithExpiresAt", testWithExpiresAt},
+		{"UpdateLease", testUpdateLease},
+		{"ExpireLease", testExpireLease},
+		{"LeaseManagement", testLeaseManagement},
+		{"Stream", testStream},
+		{"Watch", testWatch},
+		{"WatchStream", testWatchStream},
+		{"RandomSets", testRandomSets},
+		{"RandomUpdateDelete", testRandomUpdateDelete},
+		{"RandomLeases", testRandomLeases},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			test.fn(t, factory(t))
+		})
+	}
+}
+
+// AssertEvents asserts that the client stream of the prefix contains exactly the event types.
+func AssertEvents(t *testing.T, cl goku.Client, prefix string, types ...reflex.EventType) {
+	t.Helper()
+
+	sc, err := cl.Stream(prefix)(context.Background(), "", reflex.WithStreamToHead())
+	jtest.RequireNil(t, err)
+
+	for i, typ := range types {
+		e, err := sc.Recv()
+		require.NoError(t, err, "event i=%d", i)
+		require.Equal(t, typ.ReflexType(), e.Type.ReflexType(), "event i=%d", i)
+	}
+
+	_, err = sc.Recv()
+	jtest.Require(t, reflex.ErrHeadReached, err)
+}
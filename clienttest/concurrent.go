@@ -0,0 +1,98 @@
+package clienttest
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/jtest"
+	"github.com/stretchr/testify/require"
+)
+
+// concurrency is the number of concurrent goroutines of the concurrent tests.
+const concurrency = 10
+
+// runConcurrently calls fn from multiple goroutines at the same time and waits for them to complete.
+func runConcurrently(fn func(i int)) {
+	var (
+		start sync.WaitGroup
+		done  sync.WaitGroup
+	)
+	start.Add(1)
+	for i := 0; i < concurrency; i++ {
+		done.Add(1)
+		go func(i int) {
+			defer done.Done()
+			start.Wait()
+			fn(i)
+		}(i)
+	}
+
+	start.Done()
+	done.Wait()
+}
+
+func testConcurrentTxnCreate(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	var (
+		mu        sync.Mutex
+		succeeded int
+	)
+	runConcurrently(func(int) {
+		resp, err := cl.Txn(ctx,
+			[]goku.Compare{goku.CompareExists("key", false)},
+			[]goku.Op{goku.OpSet("key", nil)}, nil)
+		if errors.Is(err, goku.ErrUpdateRace) {
+			return
+		}
+		jtest.RequireNil(t, err)
+
+		if resp.Succeeded {
+			mu.Lock()
+			succeeded++
+			mu.Unlock()
+		}
+	})
+
+	require.Equal(t, 1, succeeded)
+
+	kv, err := cl.Get(ctx, "key")
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(1), kv.Version)
+}
+
+func testConcurrentCreate(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	var (
+		mu   sync.Mutex
+		keys = make(map[string]bool)
+	)
+	runConcurrently(func(int) {
+		key, err := cl.Create(ctx, "prefix/", nil)
+		jtest.RequireNil(t, err)
+
+		mu.Lock()
+		keys[key] = true
+		mu.Unlock()
+	})
+
+	require.Len(t, keys, concurrency)
+}
+
+func testConcurrentIncrement(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	runConcurrently(func(int) {
+		_, err := cl.Increment(ctx, "counter", 1)
+		jtest.RequireNil(t, err)
+	})
+
+	kv, err := cl.Get(ctx, "counter")
+	jtest.RequireNil(t, err)
+	require.Equal(t, strconv.Itoa(concurrency), string(kv.Value))
+}
@@ -0,0 +1,648 @@
+package clienttest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/jtest"
+	"github.com/luno/reflex"
+	"github.com/stretchr/testify/require"
+)
+
+func testEmptyNotFound(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	_, err := cl.Get(ctx, "")
+	jtest.Require(t, goku.ErrNotFound, err)
+
+	AssertEvents(t, cl, "")
+}
+
+func testInvalidKey(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	err := cl.Set(ctx, "", nil)
+	jtest.Require(t, goku.ErrInvalidKey, err)
+
+	err = cl.Set(ctx, strings.Repeat("s", 256), nil)
+	jtest.Require(t, goku.ErrInvalidKey, err)
+
+	AssertEvents(t, cl, "")
+}
+
+func testGet(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	const (
+		key1 = "key1"
+		key2 = "key2"
+	)
+
+	_, err := cl.Get(ctx, key1)
+	jtest.Require(t, goku.ErrNotFound, err)
+
+	err = cl.Set(ctx, key1, []byte(key1))
+	jtest.RequireNil(t, err)
+
+	err = cl.Set(ctx, key2, nil)
+	jtest.RequireNil(t, err)
+
+	kv, err := cl.Get(ctx, key1)
+	jtest.RequireNil(t, err)
+
+	require.Equal(t, int64(1), kv.Version)
+	require.Equal(t, key1, kv.Key)
+	require.Equal(t, []byte(key1), kv.Value)
+
+	kv, err = cl.Get(ctx, key2)
+	jtest.RequireNil(t, err)
+
+	require.Equal(t, int64(1), kv.Version)
+	require.Equal(t, key2, kv.Key)
+	require.Len(t, kv.Value, 0)
+
+	AssertEvents(t, cl, "", goku.EventTypeSet, goku.EventTypeSet)
+	AssertEvents(t, cl, "key", goku.EventTypeSet, goku.EventTypeSet)
+	AssertEvents(t, cl, "key1", goku.EventTypeSet)
+}
+
+func testGetSetMany(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	err := cl.SetMany(ctx, []goku.SetItem{
+		goku.NewSetItem("key1", []byte("1")),
+		goku.NewSetItem("key2", []byte("2")),
+		goku.NewSetItem("key3", []byte("3"), goku.WithLeaseID(1)),
+	})
+	jtest.RequireNil(t, err)
+
+	kvs, err := cl.GetMany(ctx, []string{"key3", "missing", "key1"})
+	jtest.RequireNil(t, err)
+	require.Len(t, kvs, 3)
+	require.Equal(t, []byte("3"), kvs[0].Value)
+	require.Equal(t, int64(1), kvs[0].LeaseID)
+	require.Equal(t, goku.KV{}, kvs[1])
+	require.Equal(t, []byte("1"), kvs[2].Value)
+
+	// Sets are atomic.
+	err = cl.SetMany(ctx, []goku.SetItem{
+		goku.NewSetItem("key4", nil),
+		goku.NewSetItem("key1", nil, goku.WithCreateOnly()),
+	})
+	jtest.Require(t, goku.ErrConditional, err)
+
+	_, err = cl.Get(ctx, "key4")
+	jtest.Require(t, goku.ErrNotFound, err)
+
+	kvs, err = cl.GetMany(ctx, nil)
+	jtest.RequireNil(t, err)
+	require.Empty(t, kvs)
+}
+
+func testList(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	const n = 20
+	for i := 0; i < n; i++ {
+		err := cl.Set(ctx, fmt.Sprintf("%d", i), nil)
+		jtest.RequireNil(t, err)
+	}
+
+	kvs, err := cl.List(ctx, "")
+	jtest.RequireNil(t, err)
+	require.Len(t, kvs, n)
+
+	kvs, err = cl.List(ctx, "1")
+	jtest.RequireNil(t, err)
+	require.Len(t, kvs, 11)
+
+	require.Equal(t, "1", kvs[0].Key)
+	require.Equal(t, "10", kvs[1].Key)
+	require.Equal(t, "19", kvs[10].Key)
+
+	var expected []reflex.EventType
+	for i := 0; i < 11; i++ {
+		expected = append(expected, goku.EventTypeSet)
+	}
+	AssertEvents(t, cl, "1", expected...)
+	AssertEvents(t, cl, "10", goku.EventTypeSet)
+}
+
+func testListOptions(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	const n = 10
+	for i := 0; i < n; i++ {
+		err := cl.Set(ctx, fmt.Sprintf("%d", i), []byte("value"))
+		jtest.RequireNil(t, err)
+	}
+
+	assertKeys := func(t *testing.T, kvs []goku.KV, keys ...string) {
+		t.Helper()
+		var actual []string
+		for _, kv := range kvs {
+			actual = append(actual, kv.Key)
+		}
+		require.Equal(t, keys, actual)
+	}
+
+	kvs, err := cl.List(ctx, "", goku.WithLimit(3))
+	jtest.RequireNil(t, err)
+	assertKeys(t, kvs, "0", "1", "2")
+
+	var cursor string
+	kvs, err = cl.List(ctx, "", goku.WithLimit(3), goku.WithStartAfter(kvs[2].Key), goku.WithCursor(&cursor))
+	jtest.RequireNil(t, err)
+	assertKeys(t, kvs, "3", "4", "5")
+	require.Equal(t, "5", cursor)
+
+	kvs, err = cl.List(ctx, "", goku.WithLimit(5), goku.WithStartAfter(cursor), goku.WithCursor(&cursor))
+	jtest.RequireNil(t, err)
+	assertKeys(t, kvs, "6", "7", "8", "9")
+	require.Empty(t, cursor)
+
+	kvs, err = cl.List(ctx, "", goku.WithStartAfter("5"), goku.WithEndKey("8"))
+	jtest.RequireNil(t, err)
+	assertKeys(t, kvs, "6", "7")
+
+	kvs, err = cl.List(ctx, "", goku.WithReverse(), goku.WithLimit(2))
+	jtest.RequireNil(t, err)
+	assertKeys(t, kvs, "9", "8")
+
+	kvs, err = cl.List(ctx, "", goku.WithReverse(), goku.WithStartAfter("8"), goku.WithEndKey("5"))
+	jtest.RequireNil(t, err)
+	assertKeys(t, kvs, "7", "6")
+
+	kvs, err = cl.List(ctx, "", goku.WithKeysOnly())
+	jtest.RequireNil(t, err)
+	require.Len(t, kvs, n)
+	for _, kv := range kvs {
+		require.Empty(t, kv.Value)
+		require.Equal(t, int64(1), kv.Version)
+	}
+}
+
+func testCreate(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	for i := 1; i <= 3; i++ {
+		key, err := cl.Create(ctx, "queue/", []byte{byte(i)})
+		jtest.RequireNil(t, err)
+		require.Equal(t, fmt.Sprintf("queue/%020d", i), key)
+	}
+
+	key, err := cl.Create(ctx, "other/", nil)
+	jtest.RequireNil(t, err)
+	require.Equal(t, "other/00000000000000000001", key)
+
+	// Sequences are not reused after deletes.
+	err = cl.Delete(ctx, "queue/00000000000000000003")
+	jtest.RequireNil(t, err)
+
+	key, err = cl.Create(ctx, "queue/", nil, goku.WithLeaseID(1))
+	jtest.RequireNil(t, err)
+	require.Equal(t, "queue/00000000000000000004", key)
+
+	kvs, err := cl.List(ctx, "queue/")
+	jtest.RequireNil(t, err)
+	require.Len(t, kvs, 3)
+	require.Equal(t, []byte{1}, kvs[0].Value)
+	require.Equal(t, int64(1), kvs[2].LeaseID)
+
+	_, err = cl.Create(ctx, strings.Repeat("a", 240), nil)
+	jtest.Require(t, goku.ErrInvalidKey, err)
+}
+
+func testIncrement(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	val, err := cl.Increment(ctx, "counter", 2)
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(2), val)
+
+	val, err = cl.Increment(ctx, "counter", -5)
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(-3), val)
+
+	kv, err := cl.Get(ctx, "counter")
+	jtest.RequireNil(t, err)
+	require.Equal(t, "-3", string(kv.Value))
+	require.Equal(t, int64(2), kv.Version)
+
+	// Lease expiry is maintained.
+	t0 := time.Now().Add(time.Hour).Round(time.Millisecond)
+	err = cl.Set(ctx, "counter", []byte("10"), goku.WithExpiresAt(t0))
+	jtest.RequireNil(t, err)
+
+	val, err = cl.Increment(ctx, "counter", 1)
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(11), val)
+
+	kv, err = cl.Get(ctx, "counter")
+	jtest.RequireNil(t, err)
+	l, err := cl.GetLease(ctx, kv.LeaseID)
+	jtest.RequireNil(t, err)
+	require.True(t, t0.Equal(l.ExpiresAt))
+
+	err = cl.Set(ctx, "text", []byte("text"))
+	jtest.RequireNil(t, err)
+
+	_, err = cl.Increment(ctx, "text", 1)
+	jtest.Require(t, goku.ErrNotInteger, err)
+
+	AssertEvents(t, cl, "counter", goku.EventTypeSet, goku.EventTypeSet,
+		goku.EventTypeSet, goku.EventTypeSet)
+}
+
+func testUpdate(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	const key = "key"
+
+	assert := func(t *testing.T, version int64, val string) {
+		t.Helper()
+
+		kv, err := cl.Get(ctx, key)
+		jtest.RequireNil(t, err)
+
+		require.Equal(t, version, kv.Version)
+		require.Equal(t, key, kv.Key)
+		require.Equal(t, val, string(kv.Value))
+	}
+
+	err := cl.Set(ctx, key, nil)
+	require.NoError(t, err)
+	assert(t, 1, "")
+
+	err = cl.Set(ctx, key, []byte("1"))
+	require.NoError(t, err)
+	assert(t, 2, "1")
+
+	err = cl.Set(ctx, key, []byte("aba"))
+	require.NoError(t, err)
+	assert(t, 3, "aba")
+
+	err = cl.Set(ctx, key, nil)
+	require.NoError(t, err)
+	assert(t, 4, "")
+}
+
+func testUpdateDelete(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	const key = "key"
+
+	assert := func(t *testing.T, version int64, val string) {
+		t.Helper()
+
+		kv, err := cl.Get(ctx, key)
+		jtest.RequireNil(t, err)
+
+		require.Equal(t, version, kv.Version)
+		require.Equal(t, key, kv.Key)
+		require.Equal(t, val, string(kv.Value))
+	}
+
+	err := cl.Set(ctx, key, nil)
+	require.NoError(t, err)
+	assert(t, 1, "")
+
+	err = cl.Set(ctx, key, []byte("1"))
+	require.NoError(t, err)
+	assert(t, 2, "1")
+
+	err = cl.Delete(ctx, key)
+	require.NoError(t, err)
+	_, err = cl.Get(ctx, key)
+	jtest.Require(t, goku.ErrNotFound, err)
+
+	err = cl.Set(ctx, key, []byte("new"))
+	require.NoError(t, err)
+	assert(t, 4, "new")
+	kv, err := cl.Get(ctx, key)
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(0), kv.DeletedRef)
+	require.Equal(t, int64(4), kv.CreatedRef)
+	require.Equal(t, int64(2), kv.LeaseID) // New LeaseID
+
+	AssertEvents(t, cl, "", goku.EventTypeSet, goku.EventTypeSet, goku.EventTypeDelete, goku.EventTypeSet)
+}
+
+func testSetWithLease(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	const (
+		key1 = "key1"
+		key2 = "key2"
+		key3 = "key3"
+	)
+
+	assert := func(t *testing.T, key string, version int64, val string) {
+		t.Helper()
+
+		kv, err := cl.Get(ctx, key)
+		jtest.RequireNil(t, err)
+
+		require.Equal(t, version, kv.Version)
+		require.Equal(t, key, kv.Key)
+		require.Equal(t, val, string(kv.Value))
+	}
+
+	err := cl.Set(ctx, key1, nil)
+	require.NoError(t, err)
+	assert(t, key1, 1, "")
+
+	err = cl.Set(ctx, key2, nil)
+	require.NoError(t, err)
+	assert(t, key2, 1, "")
+
+	kv1, err := cl.Get(ctx, key1)
+	jtest.RequireNil(t, err)
+
+	err = cl.Set(ctx, key2, nil, goku.WithLeaseID(kv1.LeaseID))
+	require.NoError(t, err)
+	assert(t, key2, 2, "")
+
+	kv2, err := cl.Get(ctx, key2)
+	jtest.RequireNil(t, err)
+	require.Equal(t, kv1.LeaseID, kv2.LeaseID)
+
+	err = cl.Set(ctx, key3, nil, goku.WithLeaseID(kv1.LeaseID))
+	require.NoError(t, err)
+	assert(t, key3, 1, "")
+
+	kv3, err := cl.Get(ctx, key3)
+	jtest.RequireNil(t, err)
+	require.Equal(t, kv1.LeaseID, kv3.LeaseID)
+
+	err = cl.Delete(ctx, key3)
+	jtest.RequireNil(t, err)
+
+	_, err = cl.Get(ctx, key1)
+	jtest.RequireNil(t, err)
+	_, err = cl.Get(ctx, key2)
+	jtest.RequireNil(t, err)
+	_, err = cl.Get(ctx, key3)
+	jtest.Require(t, goku.ErrNotFound, err)
+
+	AssertEvents(t, cl, "", goku.EventTypeSet, goku.EventTypeSet,
+		goku.EventTypeSet, goku.EventTypeSet, goku.EventTypeDelete)
+
+	AssertEvents(t, cl, key3, goku.EventTypeSet, goku.EventTypeDelete)
+}
+
+func testCreateOnly(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	const key1 = "key1"
+
+	err := cl.Set(ctx, key1, nil, goku.WithCreateOnly())
+	require.NoError(t, err)
+
+	err = cl.Set(ctx, key1, nil, goku.WithCreateOnly())
+	jtest.Require(t, goku.ErrConditional, err)
+}
+
+func testPrevVersion(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	const key1 = "key1"
+
+	err := cl.Set(ctx, key1, nil)
+	require.NoError(t, err)
+
+	err = cl.Set(ctx, key1, nil, goku.WithPrevVersion(1))
+	require.NoError(t, err)
+
+	err = cl.Set(ctx, key1, nil, goku.WithPrevVersion(1))
+	jtest.Require(t, goku.ErrConditional, err)
+}
+
+func testConditionalDelete(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	const key1 = "key1"
+
+	err := cl.Set(ctx, key1, []byte("1")) // Lease 1
+	require.NoError(t, err)
+
+	err = cl.Set(ctx, key1, []byte("2"))
+	require.NoError(t, err)
+
+	err = cl.Delete(ctx, key1, goku.WithDeletePrevVersion(1))
+	jtest.Require(t, goku.ErrConditional, err)
+
+	err = cl.Delete(ctx, key1, goku.WithDeleteIfLease(2))
+	jtest.Require(t, goku.ErrConditional, err)
+
+	err = cl.Delete(ctx, key1, goku.WithDeletePrevVersion(2), goku.WithDeleteIfLease(1))
+	jtest.RequireNil(t, err)
+
+	err = cl.Set(ctx, key1, []byte("3"))
+	require.NoError(t, err)
+
+	var kv goku.KV
+	err = cl.Delete(ctx, key1, goku.WithDeleteReturnPrev(&kv))
+	jtest.RequireNil(t, err)
+	require.Equal(t, key1, kv.Key)
+	require.Equal(t, []byte("3"), kv.Value)
+	require.Equal(t, int64(4), kv.Version)
+	require.Zero(t, kv.DeletedRef)
+
+	err = cl.Delete(ctx, key1)
+	jtest.Require(t, goku.ErrNotFound, err)
+}
+
+func testDeletePrefix(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	for _, key := range []string{"a/1", "a/2", "a/3", "a/4", "a/5", "b/1"} {
+		err := cl.Set(ctx, key, nil)
+		jtest.RequireNil(t, err)
+	}
+
+	err := cl.Delete(ctx, "a/3")
+	jtest.RequireNil(t, err)
+
+	n, err := cl.DeletePrefix(ctx, "a/", goku.WithDeleteBatchSize(2))
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(4), n)
+
+	kvs, err := cl.List(ctx, "")
+	jtest.RequireNil(t, err)
+	require.Len(t, kvs, 1)
+	require.Equal(t, "b/1", kvs[0].Key)
+
+	n, err = cl.DeletePrefix(ctx, "a/")
+	jtest.RequireNil(t, err)
+	require.Zero(t, n)
+
+	_, err = cl.DeletePrefix(ctx, "")
+	jtest.Require(t, goku.ErrInvalidKey, err)
+
+	AssertEvents(t, cl, "a/",
+		goku.EventTypeSet, goku.EventTypeSet, goku.EventTypeSet, goku.EventTypeSet, goku.EventTypeSet,
+		goku.EventTypeDelete, goku.EventTypeDelete, goku.EventTypeDelete, goku.EventTypeDelete, goku.EventTypeDelete)
+}
+
+func testTxn(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	const (
+		key1 = "key1"
+		key2 = "key2"
+	)
+
+	err := cl.Set(ctx, key1, []byte("1"))
+	jtest.RequireNil(t, err)
+
+	// Move key1 to key2 if key2 doesn't exist.
+	resp, err := cl.Txn(ctx,
+		[]goku.Compare{goku.CompareVersion(key1, 1), goku.CompareExists(key2, false)},
+		[]goku.Op{goku.OpDelete(key1), goku.OpSet(key2, []byte("1")), goku.OpGet(key2)},
+		[]goku.Op{goku.OpGet(key1)})
+	jtest.RequireNil(t, err)
+	require.True(t, resp.Succeeded)
+	require.Len(t, resp.Gets, 1)
+	require.Equal(t, key2, resp.Gets[0].Key)
+	require.Equal(t, []byte("1"), resp.Gets[0].Value)
+
+	_, err = cl.Get(ctx, key1)
+	jtest.Require(t, goku.ErrNotFound, err)
+
+	// Compare fails, so else is executed.
+	resp, err = cl.Txn(ctx,
+		[]goku.Compare{goku.CompareExists(key2, false)},
+		[]goku.Op{goku.OpSet(key2, []byte("2"))},
+		[]goku.Op{goku.OpGet(key1), goku.OpGet(key2)})
+	jtest.RequireNil(t, err)
+	require.False(t, resp.Succeeded)
+	require.Len(t, resp.Gets, 2)
+	require.Equal(t, int64(0), resp.Gets[0].Version)
+	require.Equal(t, []byte("1"), resp.Gets[1].Value)
+
+	// Failed ops rollback the whole txn.
+	_, err = cl.Txn(ctx, nil,
+		[]goku.Op{goku.OpSet(key1, nil), goku.OpSet(key2, nil, goku.WithPrevVersion(99))}, nil)
+	jtest.Require(t, goku.ErrConditional, err)
+
+	_, err = cl.Get(ctx, key1)
+	jtest.Require(t, goku.ErrNotFound, err)
+
+	// All compares are evaluated, even after a false compare.
+	_, err = cl.Txn(ctx, []goku.Compare{
+		goku.CompareExists(key2, false),
+		{Key: key1, Type: 99},
+	}, nil, nil)
+	require.Error(t, err)
+
+	AssertEvents(t, cl, "", goku.EventTypeSet, goku.EventTypeDelete, goku.EventTypeSet)
+}
+
+func testGetAtListAt(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	const (
+		key1 = "key1"
+		key2 = "key2"
+	)
+
+	err := cl.Set(ctx, key1, []byte("1")) // ref 1
+	jtest.RequireNil(t, err)
+	err = cl.Set(ctx, key2, []byte("1")) // ref 2
+	jtest.RequireNil(t, err)
+	err = cl.Set(ctx, key1, []byte("2")) // ref 3
+	jtest.RequireNil(t, err)
+	err = cl.Delete(ctx, key2) // ref 4
+	jtest.RequireNil(t, err)
+	err = cl.Set(ctx, key2, []byte("2")) // ref 5
+	jtest.RequireNil(t, err)
+
+	_, err = cl.GetAt(ctx, key2, 1)
+	jtest.Require(t, goku.ErrNotFound, err)
+
+	kv, err := cl.GetAt(ctx, key1, 2)
+	jtest.RequireNil(t, err)
+	require.Equal(t, goku.KV{Key: key1, Value: []byte("1"), Version: 1, CreatedRef: 1, UpdatedRef: 1}, kv)
+
+	kv, err = cl.GetAt(ctx, key1, 5)
+	jtest.RequireNil(t, err)
+	require.Equal(t, goku.KV{Key: key1, Value: []byte("2"), Version: 2, CreatedRef: 1, UpdatedRef: 3}, kv)
+
+	_, err = cl.GetAt(ctx, key2, 4)
+	jtest.Require(t, goku.ErrNotFound, err)
+
+	kv, err = cl.GetAt(ctx, key2, 5)
+	jtest.RequireNil(t, err)
+	require.Equal(t, goku.KV{Key: key2, Value: []byte("2"), Version: 3, CreatedRef: 5, UpdatedRef: 5}, kv)
+
+	kvs, err := cl.ListAt(ctx, "key", 2)
+	jtest.RequireNil(t, err)
+	require.Len(t, kvs, 2)
+
+	kvs, err = cl.ListAt(ctx, "key", 4)
+	jtest.RequireNil(t, err)
+	require.Len(t, kvs, 1)
+	require.Equal(t, key1, kvs[0].Key)
+	require.Equal(t, []byte("2"), kvs[0].Value)
+
+	kvs, err = cl.ListAt(ctx, "", 5)
+	jtest.RequireNil(t, err)
+	require.Len(t, kvs, 2)
+}
+
+func testHistory(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	const key = "key"
+
+	t0 := time.Now().Add(-time.Second)
+
+	err := cl.Set(ctx, key, []byte("1"))
+	jtest.RequireNil(t, err)
+	err = cl.Set(ctx, "other", nil)
+	jtest.RequireNil(t, err)
+	err = cl.Set(ctx, key, []byte("2"))
+	jtest.RequireNil(t, err)
+	err = cl.Delete(ctx, key)
+	jtest.RequireNil(t, err)
+	err = cl.Set(ctx, key, []byte("3"))
+	jtest.RequireNil(t, err)
+
+	rl, err := cl.History(ctx, key)
+	jtest.RequireNil(t, err)
+	require.Len(t, rl, 4)
+
+	expected := []struct {
+		typ   goku.EventType
+		ref   int64
+		value string
+	}{
+		{goku.EventTypeSet, 1, "1"},
+		{goku.EventTypeSet, 3, "2"},
+		{goku.EventTypeDelete, 4, ""},
+		{goku.EventTypeSet, 5, "3"},
+	}
+	for i, e := range expected {
+		require.Equal(t, key, rl[i].Key)
+		require.Equal(t, e.typ, rl[i].Type)
+		require.Equal(t, e.ref, rl[i].Ref)
+		require.Equal(t, e.value, string(rl[i].Value))
+		require.True(t, rl[i].Timestamp.After(t0))
+	}
+
+	rl, err = cl.History(ctx, key, goku.WithHistoryLimit(2), goku.WithHistoryAfter(1))
+	jtest.RequireNil(t, err)
+	require.Len(t, rl, 2)
+	require.Equal(t, int64(3), rl[0].Ref)
+	require.Equal(t, int64(4), rl[1].Ref)
+
+	rl, err = cl.History(ctx, key, goku.WithHistoryReverse(), goku.WithHistoryLimit(1))
+	jtest.RequireNil(t, err)
+	require.Len(t, rl, 1)
+	require.Equal(t, int64(5), rl[0].Ref)
+}
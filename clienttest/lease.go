@@ -0,0 +1,166 @@
+package clienttest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/jtest"
+	"github.com/stretchr/testify/require"
+)
+
+func testWithExpiresAt(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	const key = "key"
+
+	t0 := time.Now().Add(time.Hour).Round(time.Millisecond) // Future to avoid expiry, round to avoid discrepancies wrt insert and query
+
+	err := cl.Set(ctx, key, nil)
+	jtest.RequireNil(t, err)
+
+	ll, err := cl.ListLeases(ctx, goku.WithExpiresBefore(t0.Add(time.Millisecond)))
+	jtest.RequireNil(t, err)
+	require.Empty(t, ll)
+
+	err = cl.Set(ctx, key, nil, goku.WithExpiresAt(t0))
+	jtest.RequireNil(t, err)
+
+	ll, err = cl.ListLeases(ctx, goku.WithExpiresBefore(t0.Add(time.Millisecond)))
+	jtest.RequireNil(t, err)
+	require.Len(t, ll, 1)
+
+	err = cl.Set(ctx, key, nil, goku.WithExpiresAt(t0.Add(time.Minute)))
+	jtest.RequireNil(t, err)
+
+	ll, err = cl.ListLeases(ctx, goku.WithExpiresBefore(t0.Add(time.Millisecond)))
+	jtest.RequireNil(t, err)
+	require.Len(t, ll, 0)
+}
+
+func testUpdateLease(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	const key = "key"
+
+	t0 := time.Now().Add(time.Hour).Round(time.Millisecond) // Future to avoid expiry, round to avoid discrepancies wrt insert and query
+
+	err := cl.Set(ctx, key, nil)
+	jtest.RequireNil(t, err)
+
+	err = cl.UpdateLease(ctx, 1, t0)
+	jtest.RequireNil(t, err)
+
+	ll, err := cl.ListLeases(ctx, goku.WithExpiresBefore(t0.Add(time.Millisecond)))
+	jtest.RequireNil(t, err)
+	require.Len(t, ll, 1)
+
+	err = cl.Set(ctx, key, nil, goku.WithExpiresAt(t0.Add(time.Minute)))
+	jtest.RequireNil(t, err)
+
+	ll, err = cl.ListLeases(ctx, goku.WithExpiresBefore(t0.Add(time.Millisecond)))
+	jtest.RequireNil(t, err)
+	require.Len(t, ll, 0)
+}
+
+func testExpireLease(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	const key1 = "key1"
+	const key2 = "key2"
+
+	err := cl.Set(ctx, key1, nil)
+	jtest.RequireNil(t, err)
+
+	err = cl.Set(ctx, key2, nil, goku.WithLeaseID(1))
+	jtest.RequireNil(t, err)
+
+	err = cl.ExpireLease(ctx, 1)
+	jtest.RequireNil(t, err)
+
+	_, err = cl.Get(ctx, key1)
+	jtest.Require(t, goku.ErrNotFound, err)
+	err = cl.Delete(ctx, key2)
+	jtest.Require(t, goku.ErrNotFound, err)
+	err = cl.UpdateLease(ctx, 1, time.Now())
+	jtest.Require(t, goku.ErrLeaseNotFound, err)
+
+	AssertEvents(t, cl, "", goku.EventTypeSet, goku.EventTypeSet,
+		goku.EventTypeExpire, goku.EventTypeExpire)
+
+	AssertEvents(t, cl, key1, goku.EventTypeSet, goku.EventTypeExpire)
+}
+
+func testLeaseManagement(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	t0 := time.Now().Add(time.Hour).Round(time.Millisecond)
+
+	err := cl.Set(ctx, "other", nil) // Lease 1
+	jtest.RequireNil(t, err)
+
+	id, err := cl.GrantLease(ctx, t0)
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(2), id)
+
+	l, err := cl.GetLease(ctx, id)
+	jtest.RequireNil(t, err)
+	require.Equal(t, id, l.ID)
+	require.True(t, t0.Equal(l.ExpiresAt))
+	require.False(t, l.Expired)
+
+	_, err = cl.GetLease(ctx, 99)
+	jtest.Require(t, goku.ErrLeaseNotFound, err)
+
+	err = cl.Set(ctx, "key1", nil, goku.WithLeaseID(id), goku.WithExpiresAt(t0))
+	jtest.RequireNil(t, err)
+
+	// The lease expiry is left as is.
+	err = cl.Set(ctx, "key2", nil, goku.WithLeaseID(id))
+	jtest.RequireNil(t, err)
+
+	l, err = cl.GetLease(ctx, id)
+	jtest.RequireNil(t, err)
+	require.True(t, t0.Equal(l.ExpiresAt))
+
+	kvs, err := cl.ListLeaseKeys(ctx, id)
+	jtest.RequireNil(t, err)
+	require.Len(t, kvs, 2)
+	require.Equal(t, "key1", kvs[0].Key)
+	require.Equal(t, "key2", kvs[1].Key)
+
+	ll, err := cl.ListLeases(ctx)
+	jtest.RequireNil(t, err)
+	require.Len(t, ll, 2)
+
+	ll, err = cl.ListLeases(ctx, goku.WithExpiresBefore(t0.Add(time.Second)))
+	jtest.RequireNil(t, err)
+	require.Len(t, ll, 1)
+	require.Equal(t, id, ll[0].ID)
+
+	ll, err = cl.ListLeases(ctx, goku.WithLeasesAfter(1), goku.WithLeasesLimit(1))
+	jtest.RequireNil(t, err)
+	require.Len(t, ll, 1)
+	require.Equal(t, id, ll[0].ID)
+
+	err = cl.ExpireLease(ctx, id)
+	jtest.RequireNil(t, err)
+
+	kvs, err = cl.ListLeaseKeys(ctx, id)
+	jtest.RequireNil(t, err)
+	require.Empty(t, kvs)
+
+	l, err = cl.GetLease(ctx, id)
+	jtest.RequireNil(t, err)
+	require.True(t, l.Expired)
+	require.True(t, l.ExpiresAt.IsZero())
+
+	ll, err = cl.ListLeases(ctx)
+	jtest.RequireNil(t, err)
+	require.Len(t, ll, 1)
+
+	ll, err = cl.ListLeases(ctx, goku.WithIncludeExpired())
+	jtest.RequireNil(t, err)
+	require.Len(t, ll, 2)
+}
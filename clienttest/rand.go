@@ -1,4 +1,4 @@
-package test
+package clienttest
 
 import (
 	"context"
@@ -15,18 +15,16 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestRandomSets(t *testing.T) {
+func testRandomSets(t *testing.T, cl goku.Client) {
 	ctx := context.Background()
 	rand.Seed(time.Now().UnixNano())
-	cl, dbc := SetupForTesting(t)
-	dbc.SetMaxOpenConns(100)
 
 	var wg sync.WaitGroup
 	uniq := make(map[string]bool)
 	const n = 1000
 
 	for i := 0; i < n; i++ {
-		key := uniqKey(uniq)
+		key := UniqKey(uniq)
 
 		wg.Add(1)
 		go func() {
@@ -62,11 +60,9 @@ func TestRandomSets(t *testing.T) {
 	jtest.Require(t, reflex.ErrHeadReached, err)
 }
 
-func TestRandomUpdateDelete(t *testing.T) {
+func testRandomUpdateDelete(t *testing.T, cl goku.Client) {
 	ctx := context.Background()
 	rand.Seed(time.Now().UnixNano())
-	cl, dbc := SetupForTesting(t)
-	dbc.SetMaxOpenConns(100)
 
 	var wg sync.WaitGroup
 	uniq := make(map[string]bool)
@@ -74,7 +70,7 @@ func TestRandomUpdateDelete(t *testing.T) {
 
 	// Add some keys
 	for i := 0; i < n; i++ {
-		key := uniqKey(uniq)
+		key := UniqKey(uniq)
 
 		wg.Add(1)
 		go func() {
@@ -139,11 +135,9 @@ func TestRandomUpdateDelete(t *testing.T) {
 	}
 }
 
-func TestRandomLeases(t *testing.T) {
+func testRandomLeases(t *testing.T, cl goku.Client) {
 	ctx := context.Background()
 	rand.Seed(time.Now().UnixNano())
-	cl, dbc := SetupForTesting(t)
-	dbc.SetMaxOpenConns(100)
 
 	var wg sync.WaitGroup
 	uniq := make(map[string]bool)
@@ -151,7 +145,7 @@ func TestRandomLeases(t *testing.T) {
 
 	// Add parent keys
 	for i := 0; i < parents; i++ {
-		parent := uniqKey(uniq)
+		parent := UniqKey(uniq)
 		wg.Add(1)
 		go func() {
 			err := cl.Set(ctx, parent, nil)
@@ -167,8 +161,8 @@ func TestRandomLeases(t *testing.T) {
 	// Add some children with parent leases, update and expire the lease.
 	for i := 0; i < parents; i++ {
 		leaseID := int64(1 + i)
-		child1 := uniqKey(uniq)
-		child2 := uniqKey(uniq)
+		child1 := UniqKey(uniq)
+		child2 := UniqKey(uniq)
 		wg.Add(4)
 
 		go func() {
@@ -227,17 +221,19 @@ func TestRandomLeases(t *testing.T) {
 	}
 }
 
-func uniqKey(uniq map[string]bool) string {
+// UniqKey returns a random hex key not in uniq and adds it to uniq.
+func UniqKey(uniq map[string]bool) string {
 	klen := 1 + rand.Intn(255/2)
-	key := genRand(klen)
+	key := GenRand(klen)
 	for uniq[key] {
-		key = genRand(klen)
+		key = GenRand(klen)
 	}
 	uniq[key] = true
 	return key
 }
 
-func genRand(n int) string {
+// GenRand returns a hex encoded string of n random bytes.
+func GenRand(n int) string {
 	b := make([]byte, n)
 	_, err := rand.Read(b)
 	if err != nil {
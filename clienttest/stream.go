@@ -0,0 +1,129 @@
+package clienttest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/jtest"
+	"github.com/luno/reflex"
+	"github.com/stretchr/testify/require"
+)
+
+func testStream(t *testing.T, cl goku.Client) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sc, err := cl.Stream("")(ctx, "")
+	jtest.RequireNil(t, err)
+
+	ch := make(chan reflex.EventType)
+
+	go func() {
+		for {
+			e, err := sc.Recv()
+			if err != nil && strings.Contains(strings.ToLower(err.Error()), "canceled") {
+				return
+			}
+			jtest.RequireNil(t, err)
+			ch <- e.Type
+		}
+	}()
+
+	err = cl.Set(ctx, "key1", nil)
+	jtest.RequireNil(t, err)
+	require.Equal(t, goku.EventTypeSet.ReflexType(), (<-ch).ReflexType())
+
+	err = cl.Set(ctx, "key2", nil, goku.WithLeaseID(1))
+	jtest.RequireNil(t, err)
+	require.Equal(t, goku.EventTypeSet.ReflexType(), (<-ch).ReflexType())
+
+	err = cl.Delete(ctx, "key1")
+	jtest.RequireNil(t, err)
+	require.Equal(t, goku.EventTypeDelete.ReflexType(), (<-ch).ReflexType())
+
+	err = cl.ExpireLease(ctx, 1)
+	jtest.RequireNil(t, err)
+	require.Equal(t, goku.EventTypeExpire.ReflexType(), (<-ch).ReflexType())
+}
+
+func testWatch(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	err := cl.Set(ctx, "watch/1", []byte("1"))
+	jtest.RequireNil(t, err)
+
+	err = cl.Set(ctx, "other", nil)
+	jtest.RequireNil(t, err)
+
+	snap, sc, err := goku.Watch(ctx, cl, "watch/")
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(2), snap.Ref)
+	require.Empty(t, snap.Pending)
+	require.Len(t, snap.KVs, 1)
+	require.Equal(t, "watch/1", snap.KVs[0].Key)
+
+	err = cl.Set(ctx, "watch/2", []byte("2")) // Ref 3
+	jtest.RequireNil(t, err)
+
+	e, err := sc.Recv()
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(3), e.IDInt())
+	require.Equal(t, "watch/2", e.ForeignID)
+}
+
+func testWatchStream(t *testing.T, cl goku.Client) {
+	ctx := context.Background()
+
+	err := cl.Set(ctx, "key", []byte("1")) // Lease 1
+	jtest.RequireNil(t, err)
+
+	err = cl.Set(ctx, "key", []byte("2"))
+	jtest.RequireNil(t, err)
+
+	err = cl.Delete(ctx, "key")
+	jtest.RequireNil(t, err)
+
+	sc, err := cl.WatchStream("key")(ctx, "", reflex.WithStreamToHead())
+	jtest.RequireNil(t, err)
+
+	expect := []goku.WatchEvent{
+		{Key: "key", Type: goku.EventTypeSet, Value: []byte("1"), Version: 1, LeaseID: 1, Ref: 1},
+		{Key: "key", Type: goku.EventTypeSet, Value: []byte("2"), Version: 2, LeaseID: 1, Ref: 2},
+		{Key: "key", Type: goku.EventTypeDelete, Version: 3, LeaseID: 1, Ref: 3},
+	}
+
+	for _, exp := range expect {
+		e, err := sc.Recv()
+		jtest.RequireNil(t, err)
+		require.False(t, e.Timestamp.IsZero())
+		e.Timestamp = time.Time{}
+		require.Equal(t, exp, e)
+	}
+
+	_, err = sc.Recv()
+	jtest.Require(t, reflex.ErrHeadReached, err)
+
+	// All keys match the empty prefix.
+	sc, err = cl.WatchStream("")(ctx, "2", reflex.WithStreamToHead())
+	jtest.RequireNil(t, err)
+
+	e, err := sc.Recv()
+	jtest.RequireNil(t, err)
+	e.Timestamp = time.Time{}
+	require.Equal(t, expect[2], e)
+
+	// Decoded reflex events don't include version and lease id.
+	rsc, err := cl.Stream("key")(ctx, "1")
+	jtest.RequireNil(t, err)
+
+	e, err = goku.NewWatchStream(rsc).Recv()
+	jtest.RequireNil(t, err)
+	require.Equal(t, "key", e.Key)
+	require.Equal(t, goku.EventTypeSet, e.Type)
+	require.Equal(t, []byte("2"), e.Value)
+	require.Equal(t, int64(2), e.Ref)
+	require.Zero(t, e.Version)
+}
@@ -0,0 +1,3 @@
+// Package concurrency provides distributed mutexes, leader elections and sessions built on
+// goku leases. Note that lease expiry depends on db.ExpireLeasesForever.
+package concurrency
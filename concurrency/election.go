@@ -0,0 +1,177 @@
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/log"
+)
+
+// Election provides leader election implemented with a goku create-only key associated
+// with a new lease. The leader keeps the lease alive while leading and steps down
+// when the lease is lost, for example if the leader cannot reach goku for half the TTL.
+type Election struct {
+	cl  goku.Client
+	key string
+	ttl time.Duration
+
+	mu      sync.Mutex
+	leaseID int64
+	cancel  context.CancelFunc
+}
+
+// NewElection returns a new election using the key as leader key. Leadership expires after
+// the TTL if the lease cannot be kept alive.
+func NewElection(cl goku.Client, key string, ttl time.Duration) *Election {
+	return &Election{
+		cl:  cl,
+		key: key,
+		ttl: ttl,
+	}
+}
+
+// Campaign blocks until elected leader or the context is canceled. The value is stored
+// as leader key value, it should identify the candidate. It returns a leadership context
+// that is canceled when leadership is lost; either on Resign, lease expiry or when
+// the parent context is canceled.
+func (e *Election) Campaign(ctx context.Context, value []byte) (context.Context, error) {
+	for {
+		now := time.Now()
+		resp, err := e.cl.Txn(ctx,
+			[]goku.Compare{goku.CompareExists(e.key, false)},
+			[]goku.Op{
+				goku.OpSet(e.key, value, goku.WithCreateOnly(), goku.WithExpiresAt(now.Add(e.ttl))),
+				goku.OpGet(e.key),
+			}, nil)
+		if err != nil {
+			return nil, err
+		} else if resp.Succeeded {
+			return e.lead(ctx, resp.Gets[0], now), nil
+		}
+
+		err = waitDeleted(ctx, e.cl, e.key)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// lead starts keeping the lease alive and watching the leader key. It returns
+// the leadership context. Renewed is the time the lease expiry was set.
+func (e *Election) lead(ctx context.Context, kv goku.KV, renewed time.Time) context.Context {
+	ctx, cancel := context.WithCancel(ctx)
+
+	e.mu.Lock()
+	e.leaseID = kv.LeaseID
+	e.cancel = cancel
+	e.mu.Unlock()
+
+	// Step down when the leader key is deleted or expired.
+	go func() {
+		defer cancel()
+		_, err := waitEvent(ctx, e.cl, e.key, kv.CreatedRef, goku.EventTypeDelete, goku.EventTypeExpire)
+		if err != nil && ctx.Err() == nil {
+			// ReturnNoErr: Step down if unsure.
+			log.Error(ctx, errors.Wrap(err, "watch leader key"))
+		}
+	}()
+
+	// Keep the lease alive, step down before it expires if this fails.
+	go func() {
+		defer cancel()
+		keepAlive(ctx, e.cl, kv.LeaseID, e.ttl, renewed)
+	}()
+
+	return ctx
+}
+
+// Resign steps down as leader by expiring the leader lease. It returns ErrNotLeader
+// if not the leader.
+func (e *Election) Resign(ctx context.Context) error {
+	e.mu.Lock()
+	leaseID, cancel := e.leaseID, e.cancel
+	e.leaseID, e.cancel = 0, nil
+	e.mu.Unlock()
+
+	if leaseID == 0 {
+		return errors.Wrap(ErrNotLeader, "")
+	}
+
+	cancel()
+
+	err := e.cl.ExpireLease(ctx, leaseID)
+	if errors.Is(err, goku.ErrLeaseNotFound) {
+		return errors.Wrap(ErrNotLeader, "lease expired")
+	}
+
+	return err
+}
+
+// Leader returns the current leader key-value or ErrNoLeader.
+func (e *Election) Leader(ctx context.Context) (goku.KV, error) {
+	kv, err := e.cl.Get(ctx, e.key)
+	if errors.Is(err, goku.ErrNotFound) {
+		return goku.KV{}, errors.Wrap(ErrNoLeader, "")
+	} else if err != nil {
+		return goku.KV{}, err
+	}
+
+	return kv, nil
+}
+
+// Observe returns a channel of leader key-values; first the current leader (if any) and then
+// each newly elected leader. The channel is closed when the context is canceled or on error.
+func (e *Election) Observe(ctx context.Context) <-chan goku.KV {
+	ch := make(chan goku.KV)
+
+	go func() {
+		defer close(ch)
+
+		kv, after, err := getWithCursor(ctx, e.cl, e.key)
+		if errors.Is(err, goku.ErrNotFound) {
+			// Wait for first leader below.
+		} else if err != nil {
+			log.Error(ctx, errors.Wrap(err, "observe leader"))
+			return
+		} else {
+			select {
+			case ch <- kv:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		for {
+			ev, err := waitEvent(ctx, e.cl, e.key, after, goku.EventTypeSet)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Error(ctx, errors.Wrap(err, "observe leader"))
+				}
+				return
+			}
+			after = ev.IDInt()
+
+			kv, err := e.Leader(ctx)
+			if errors.Is(err, ErrNoLeader) {
+				// Leader already gone, wait for next.
+				continue
+			} else if err != nil {
+				log.Error(ctx, errors.Wrap(err, "observe leader"))
+				return
+			} else if kv.UpdatedRef < after {
+				continue
+			}
+
+			select {
+			case ch <- kv:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
@@ -0,0 +1,58 @@
+package concurrency_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/corverroos/goku/client/memory"
+	"github.com/corverroos/goku/concurrency"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/jtest"
+	"github.com/stretchr/testify/require"
+)
+
+// failingClient fails all lease updates, or blocks them until the context is canceled if hang is true.
+type failingClient struct {
+	goku.Client
+	hang bool
+}
+
+func (c failingClient) UpdateLease(ctx context.Context, _ int64, _ time.Time) error {
+	if c.hang {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	return errors.New("update lease failed")
+}
+
+// newFailingClient returns a failing client with a frozen clock so that leases don't expire by themselves.
+func newFailingClient(hang bool) (failingClient, *memory.Clock) {
+	clock := memory.NewClock(time.Now())
+	return failingClient{Client: memory.New(memory.WithClock(clock)), hang: hang}, clock
+}
+
+func TestElectionKeepAliveFailure(t *testing.T) {
+	const ttl = time.Millisecond * 300
+
+	for _, hang := range []bool{false, true} {
+		ctx := context.Background()
+		cl, _ := newFailingClient(hang)
+		e := concurrency.NewElection(cl, "leader", ttl)
+
+		t0 := time.Now()
+		lctx, err := e.Campaign(ctx, []byte("e"))
+		jtest.RequireNil(t, err)
+
+		select {
+		case <-lctx.Done():
+		case <-time.After(ttl * 3):
+			require.Fail(t, "leader didn't step down")
+		}
+
+		// Stepped down before the lease expired.
+		require.Less(t, int64(time.Since(t0)), int64(ttl))
+	}
+}
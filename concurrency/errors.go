@@ -0,0 +1,13 @@
+package concurrency
+
+import (
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+)
+
+var (
+	ErrLocked    = errors.New("mutex locked by another holder", j.C("ERR_c95e4d801d6303ea"))
+	ErrNotLocked = errors.New("mutex not locked", j.C("ERR_27b3e2632d0542bd"))
+	ErrNoLeader  = errors.New("election has no leader", j.C("ERR_3058b7da28d2d62a"))
+	ErrNotLeader = errors.New("not the election leader", j.C("ERR_a374f874713b3179"))
+)
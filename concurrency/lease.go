@@ -0,0 +1,52 @@
+package concurrency
+
+import (
+	"context"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/jettison/log"
+)
+
+// keepAlive extends the lease expiry to the TTL from now every third of the TTL until the context
+// is canceled or the lease is lost. The lease is lost if it is not found or if it was not extended
+// for half the TTL, so it is considered lost well before it actually expires. Renewed is
+// the time the lease expiry was last set.
+func keepAlive(ctx context.Context, cl goku.Client, leaseID int64, ttl time.Duration, renewed time.Time) {
+	t := time.NewTicker(ttl / 3)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		now := time.Now()
+		err := updateLease(ctx, cl, leaseID, now.Add(ttl), ttl/4)
+		if errors.Is(err, goku.ErrLeaseNotFound) {
+			return
+		} else if err != nil && ctx.Err() == nil {
+			// ReturnNoErr: Try again, the lease is lost if this keeps failing.
+			log.Error(ctx, errors.Wrap(err, "keep lease alive", j.KV("lease", leaseID)))
+		} else {
+			renewed = now
+		}
+
+		if time.Since(renewed) >= ttl/2 {
+			// The next renewal may be too late.
+			return
+		}
+	}
+}
+
+// updateLease updates the lease expiry with a timeout so that a hung call doesn't block keepAlive.
+func updateLease(ctx context.Context, cl goku.Client, leaseID int64, expiresAt time.Time, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return cl.UpdateLease(ctx, leaseID, expiresAt)
+}
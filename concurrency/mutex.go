@@ -0,0 +1,151 @@
+package concurrency
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/reflex"
+)
+
+// Mutex is a distributed mutual exclusion lock implemented with a goku create-only key
+// associated with a new lease. The lock is released on Unlock or when the lease expires after the TTL.
+//
+// A Mutex instance may only be locked by one goroutine at a time.
+type Mutex struct {
+	cl  goku.Client
+	key string
+	ttl time.Duration
+
+	leaseID int64
+}
+
+// NewMutex returns a new mutex using the key as lock. The lock expires after the TTL if not unlocked.
+func NewMutex(cl goku.Client, key string, ttl time.Duration) *Mutex {
+	return &Mutex{
+		cl:  cl,
+		key: key,
+		ttl: ttl,
+	}
+}
+
+// TryLock acquires the lock and returns a fencing token or ErrLocked if it is held by another holder.
+//
+// The fencing token is the lock key's CreatedRef. It monotonically increases each time the
+// lock is acquired and can be used to reject stale holders.
+func (m *Mutex) TryLock(ctx context.Context) (int64, error) {
+	resp, err := m.cl.Txn(ctx,
+		[]goku.Compare{goku.CompareExists(m.key, false)},
+		[]goku.Op{
+			goku.OpSet(m.key, nil, goku.WithCreateOnly(), goku.WithExpiresAt(time.Now().Add(m.ttl))),
+			goku.OpGet(m.key),
+		}, nil)
+	if err != nil {
+		return 0, err
+	} else if !resp.Succeeded {
+		return 0, errors.Wrap(ErrLocked, "")
+	}
+
+	kv := resp.Gets[0]
+	m.leaseID = kv.LeaseID
+
+	return kv.CreatedRef, nil
+}
+
+// Lock blocks until the lock is acquired or the context is canceled. It returns a fencing token,
+// see TryLock for details.
+func (m *Mutex) Lock(ctx context.Context) (int64, error) {
+	for {
+		token, err := m.TryLock(ctx)
+		if errors.Is(err, ErrLocked) {
+			// Wait below
+		} else if err != nil {
+			return 0, err
+		} else {
+			return token, nil
+		}
+
+		err = waitDeleted(ctx, m.cl, m.key)
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Unlock releases the lock by expiring its lease. It returns ErrNotLocked if the lock
+// was not acquired or if it has already expired.
+func (m *Mutex) Unlock(ctx context.Context) error {
+	if m.leaseID == 0 {
+		return errors.Wrap(ErrNotLocked, "")
+	}
+
+	err := m.cl.ExpireLease(ctx, m.leaseID)
+	m.leaseID = 0
+	if errors.Is(err, goku.ErrLeaseNotFound) {
+		return errors.Wrap(ErrNotLocked, "lease expired")
+	}
+
+	return err
+}
+
+// waitDeleted blocks until the key is deleted or expired or the context is canceled.
+func waitDeleted(ctx context.Context, cl goku.Client, key string) error {
+	_, cursor, err := getWithCursor(ctx, cl, key)
+	if errors.Is(err, goku.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	_, err = waitEvent(ctx, cl, key, cursor, goku.EventTypeDelete, goku.EventTypeExpire)
+	return err
+}
+
+// getWithCursor returns the key-value of the key and a stream cursor consistent with it, streaming after
+// the cursor returns all subsequent events of the key. Unlike the key-value's UpdatedRef, the
+// cursor is recent, so streaming after it doesn't fail with goku.ErrCompacted.
+//
+// It returns goku.ErrNotFound and the cursor if the key doesn't exist.
+func getWithCursor(ctx context.Context, cl goku.Client, key string) (goku.KV, int64, error) {
+	snap, err := cl.ListSnapshot(ctx, key)
+	if err != nil {
+		return goku.KV{}, 0, err
+	}
+
+	// The snapshot lists all keys with the key as prefix, only the exact key applies.
+	// Pending events are not filtered by key, so the cursor is consistent for any key.
+	for _, kv := range snap.KVs {
+		if kv.Key == key {
+			return kv, snap.After(), nil
+		}
+	}
+
+	return goku.KV{}, snap.After(), errors.Wrap(goku.ErrNotFound, "")
+}
+
+// waitEvent blocks until an event of any of the types for the key after the ref
+// or the context is canceled. It returns the event.
+func waitEvent(ctx context.Context, cl goku.Client, key string, after int64,
+	types ...reflex.EventType) (*reflex.Event, error) {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sc, err := cl.Stream(key)(ctx, strconv.FormatInt(after, 10))
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		e, err := sc.Recv()
+		if err != nil {
+			return nil, err
+		}
+
+		if e.ForeignID == key && reflex.IsAnyType(e.Type, types...) {
+			return e, nil
+		}
+	}
+}
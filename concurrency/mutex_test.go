@@ -0,0 +1,54 @@
+package concurrency_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/corverroos/goku/client/memory"
+	"github.com/corverroos/goku/concurrency"
+	"github.com/luno/jettison/jtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMutexPrefixKeys(t *testing.T) {
+	ctx := context.Background()
+	cl := memory.New()
+
+	// The key of m1 is a prefix of the key of m2.
+	m1 := concurrency.NewMutex(cl, "lock/a", time.Minute)
+	m2 := concurrency.NewMutex(cl, "lock/ab", time.Minute)
+
+	_, err := m2.TryLock(ctx)
+	jtest.RequireNil(t, err)
+
+	// Not locked by m2.
+	_, err = m1.TryLock(ctx)
+	jtest.RequireNil(t, err)
+
+	locked := make(chan struct{})
+	go func() {
+		_, err := concurrency.NewMutex(cl, "lock/a", time.Minute).Lock(ctx)
+		jtest.RequireNil(t, err)
+		close(locked)
+	}()
+
+	// Unlocking m2 doesn't release m1.
+	err = m2.Unlock(ctx)
+	jtest.RequireNil(t, err)
+
+	select {
+	case <-locked:
+		require.Fail(t, "locked while held")
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	err = m1.Unlock(ctx)
+	jtest.RequireNil(t, err)
+
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		require.Fail(t, "not locked after unlock")
+	}
+}
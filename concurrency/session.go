@@ -0,0 +1,79 @@
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/errors"
+)
+
+// Session is a lease that is kept alive in the background. Keys are attached to the
+// session via goku.WithLeaseID(session.LeaseID()), which leaves the lease expiry as is,
+// and are deleted when the session is closed or the lease expires.
+type Session struct {
+	cl      goku.Client
+	leaseID int64
+
+	cancel    context.CancelFunc
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewSession returns a new session by granting a new lease that expires after the TTL.
+// The lease is kept alive in the background until the session is closed or lost.
+func NewSession(ctx context.Context, cl goku.Client, ttl time.Duration) (*Session, error) {
+	now := time.Now()
+	leaseID, err := cl.GrantLease(ctx, now.Add(ttl))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := &Session{
+		cl:      cl,
+		leaseID: leaseID,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+
+	go func() {
+		defer s.closeDone()
+		keepAlive(ctx, cl, leaseID, ttl, now)
+	}()
+
+	return s, nil
+}
+
+// LeaseID returns the session lease id.
+func (s *Session) LeaseID() int64 {
+	return s.leaseID
+}
+
+// Done returns a channel that is closed when the session is closed or lost; ie. when the lease
+// expired or could not be kept alive for half the TTL, which is before it expires.
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close stops keeping the lease alive and expires it which deletes all the session keys.
+func (s *Session) Close(ctx context.Context) error {
+	s.cancel()
+	s.closeDone()
+
+	err := s.cl.ExpireLease(ctx, s.leaseID)
+	if errors.Is(err, goku.ErrLeaseNotFound) {
+		// Already expired
+		return nil
+	}
+
+	return err
+}
+
+func (s *Session) closeDone() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+}
@@ -0,0 +1,45 @@
+package concurrency_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/corverroos/goku/concurrency"
+	"github.com/luno/jettison/jtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionKeyExpires(t *testing.T) {
+	ctx := context.Background()
+	const ttl = time.Millisecond * 300
+
+	for _, hang := range []bool{false, true} {
+		// The lease is never kept alive, like when the process dies.
+		cl, clock := newFailingClient(hang)
+
+		s, err := concurrency.NewSession(ctx, cl, ttl)
+		jtest.RequireNil(t, err)
+
+		err = cl.Set(ctx, "worker", nil, goku.WithLeaseID(s.LeaseID()))
+		jtest.RequireNil(t, err)
+
+		// The session is lost before the lease expires.
+		t0 := time.Now()
+		select {
+		case <-s.Done():
+		case <-time.After(ttl * 3):
+			require.Fail(t, "session not lost")
+		}
+		require.Less(t, int64(time.Since(t0)), int64(ttl))
+
+		_, err = cl.Get(ctx, "worker")
+		jtest.RequireNil(t, err)
+
+		clock.Add(ttl * 2)
+
+		_, err = cl.Get(ctx, "worker")
+		jtest.Require(t, goku.ErrNotFound, err)
+	}
+}
@@ -0,0 +1,212 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/jettison/log"
+	"github.com/luno/reflex"
+)
+
+var ErrConsumerBehind = errors.New("consumer cursor behind compaction ref", j.C("ERR_20473c5dfd03c5ba"))
+
+const compactBatch = 1000
+
+// ConsumerCursor identifies the cursor of a reflex consumer of goku events.
+type ConsumerCursor struct {
+	Name  string
+	Store reflex.CursorStore
+}
+
+type CompactReq struct {
+	// Ref compacts events up to and including this event id.
+	Ref int64
+
+	// Before compacts events with timestamps before this time. If both Ref and Before
+	// are provided, the lowest resulting ref is used.
+	Before time.Time
+
+	// Consumers are checked before compacting. Compaction fails with ErrConsumerBehind
+	// if any consumer cursor is behind the compaction ref.
+	Consumers []ConsumerCursor
+
+	// Force compacts (and only logs) even if consumers are behind.
+	Force bool
+}
+
+// Compact compacts the event log up to a ref by nulling the metadata (values) of
+// all events superseded by later events (of the same key) up to the ref. The latest event per
+// key up to the ref is kept, so the state of all key-values at the ref can still be reconstructed.
+//
+// Event rows are never deleted since reflex relies on consecutive event ids. Streaming
+// after and point-in-time reads at refs before the compaction ref return goku.ErrCompacted.
+//
+// Compaction resumes after the last completed compaction, so events of a failed compaction are
+// compacted by the next.
+func Compact(ctx context.Context, dbc *sql.DB, req CompactReq) error {
+	ref := req.Ref
+	if !req.Before.IsZero() {
+		before, err := getMaxEventIDBefore(ctx, dbc, req.Before)
+		if err != nil {
+			return err
+		}
+
+		if ref == 0 || before < ref {
+			ref = before
+		}
+	}
+
+	prev, err := getCompletedRef(ctx, dbc)
+	if err != nil {
+		return err
+	} else if ref <= prev {
+		return nil
+	}
+
+	for _, c := range req.Consumers {
+		cursor, err := getConsumerCursor(ctx, c)
+		if err != nil {
+			return err
+		}
+
+		if cursor >= ref {
+			continue
+		}
+
+		err = errors.Wrap(ErrConsumerBehind, "", j.MKV{
+			"consumer": c.Name, "cursor": cursor, "ref": ref})
+		if !req.Force {
+			return err
+		}
+
+		// ReturnNoErr: Forced compaction, just log.
+		log.Error(ctx, err)
+	}
+
+	d := getDriver(dbc)
+
+	// Insert the compaction point first to protect streams from partially compacted events.
+	id, err := insertID(ctx, dbc, d, "insert into compactions (ref, created_at) values (?, ?)",
+		ref, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	// Batch by superseding event, since events before prev may be superseded by events after it.
+	for from := prev + 1; from <= ref; from += compactBatch {
+		to := from + compactBatch - 1
+		if to > ref {
+			to = ref
+		}
+
+		_, err := dbc.ExecContext(ctx, d.CompactEvents(), from, to)
+		if err != nil {
+			return errors.Wrap(err, "compact events")
+		}
+	}
+
+	return execOne(ctx, dbc, "update compactions set completed=true where id=?", id)
+}
+
+// CompactForever continuously compacts events older than the retention period with a polling
+// period of 1 hour. Compaction is limited to the slowest consumer cursor.
+func CompactForever(dbc *sql.DB, retention time.Duration, consumers ...ConsumerCursor) {
+	const period = time.Hour
+
+	for {
+		ctx := context.Background()
+
+		err := compactOnce(ctx, dbc, time.Now().Add(-retention), consumers)
+		if err != nil {
+			// ReturnNoErr: Log and try again next period.
+			log.Error(ctx, errors.Wrap(err, "compact events"))
+		}
+
+		time.Sleep(period)
+	}
+}
+
+// compactOnce compacts events before the cutoff, but not after the slowest consumer cursor.
+func compactOnce(ctx context.Context, dbc *sql.DB, cutoff time.Time, consumers []ConsumerCursor) error {
+	ref, err := getMaxEventIDBefore(ctx, dbc, cutoff)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range consumers {
+		cursor, err := getConsumerCursor(ctx, c)
+		if err != nil {
+			return err
+		}
+
+		if cursor < ref {
+			log.Info(ctx, "compaction limited by consumer",
+				j.MKV{"consumer": c.Name, "cursor": cursor, "ref": ref})
+			ref = cursor
+		}
+	}
+
+	return Compact(ctx, dbc, CompactReq{Ref: ref, Consumers: consumers})
+}
+
+// GetCompactedRef returns the event log compaction ref. Events up to and including
+// this ref may have been compacted. It returns zero if the event log has not been compacted.
+func GetCompactedRef(ctx context.Context, dbc dbc) (int64, error) {
+	var ref sql.NullInt64
+	err := dbc.QueryRowContext(ctx, "select max(ref) from compactions").Scan(&ref)
+	if err != nil {
+		return 0, err
+	}
+
+	return ref.Int64, nil
+}
+
+// getCompletedRef returns the ref of the last completed compaction. Events up to and including
+// this ref have been compacted.
+func getCompletedRef(ctx context.Context, dbc dbc) (int64, error) {
+	var ref sql.NullInt64
+	err := dbc.QueryRowContext(ctx, "select max(ref) from compactions where completed=true").Scan(&ref)
+	if err != nil {
+		return 0, err
+	}
+
+	return ref.Int64, nil
+}
+
+// checkCompacted returns goku.ErrCompacted if the ref is before the event log compaction ref.
+func checkCompacted(ctx context.Context, dbc dbc, ref int64) error {
+	compacted, err := GetCompactedRef(ctx, dbc)
+	if err != nil {
+		return err
+	} else if ref < compacted {
+		return errors.Wrap(goku.ErrCompacted, "", j.MKV{"ref": ref, "compacted": compacted})
+	}
+
+	return nil
+}
+
+func getMaxEventIDBefore(ctx context.Context, dbc *sql.DB, t time.Time) (int64, error) {
+	var id sql.NullInt64
+	err := dbc.QueryRowContext(ctx, "select max(id) from events where timestamp<?", t.UTC()).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id.Int64, nil
+}
+
+func getConsumerCursor(ctx context.Context, c ConsumerCursor) (int64, error) {
+	cursor, err := c.Store.GetCursor(ctx, c.Name)
+	if err != nil {
+		return 0, err
+	} else if cursor == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseInt(cursor, 10, 64)
+}
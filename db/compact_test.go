@@ -0,0 +1,73 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/jtest"
+	"github.com/luno/reflex"
+	"github.com/luno/reflex/rpatterns"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompact(t *testing.T) {
+	ctx := context.Background()
+	dbc := ConnectForTesting(t)
+
+	set := func(key, value string) {
+		err := Set(ctx, dbc, SetReq{Key: key, Value: []byte(value)})
+		jtest.RequireNil(t, err)
+	}
+
+	set("key1", "1") // ref 1
+	set("key2", "1") // ref 2
+	set("key1", "2") // ref 3
+	set("key2", "2") // ref 4
+	set("key1", "3") // ref 5
+
+	cursors := rpatterns.MemCursorStore()
+	err := cursors.SetCursor(ctx, "consumer", "2")
+	jtest.RequireNil(t, err)
+	consumers := []ConsumerCursor{{Name: "consumer", Store: cursors}}
+
+	err = Compact(ctx, dbc, CompactReq{Ref: 3, Consumers: consumers})
+	jtest.Require(t, ErrConsumerBehind, err)
+
+	err = cursors.SetCursor(ctx, "consumer", "3")
+	jtest.RequireNil(t, err)
+
+	err = Compact(ctx, dbc, CompactReq{Ref: 3, Consumers: consumers})
+	jtest.RequireNil(t, err)
+
+	ref, err := GetCompactedRef(ctx, dbc)
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(3), ref)
+
+	// Only superseded ref 1 is compacted.
+	var values []string
+	err = History(ctx, dbc, "key1", goku.HistoryOptions{}, func(r goku.Revision) error {
+		values = append(values, string(r.Value))
+		return nil
+	})
+	jtest.RequireNil(t, err)
+	require.Equal(t, []string{"", "2", "3"}, values)
+
+	_, err = GetAt(ctx, dbc, "key1", 2)
+	jtest.Require(t, goku.ErrCompacted, err)
+
+	kv, err := GetAt(ctx, dbc, "key2", 3)
+	jtest.RequireNil(t, err)
+	require.Equal(t, "1", string(kv.Value))
+
+	for _, after := range []string{"", "2"} {
+		_, err = ToStream(dbc)(ctx, after)
+		jtest.Require(t, goku.ErrCompacted, err)
+	}
+
+	sc, err := ToStream(dbc)(ctx, "3", reflex.WithStreamToHead())
+	jtest.RequireNil(t, err)
+	e, err := sc.Recv()
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(4), e.IDInt())
+}
@@ -3,12 +3,14 @@ package db
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/corverroos/goku"
-	"github.com/go-sql-driver/mysql"
 	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
 	"github.com/luno/reflex"
 )
 
@@ -16,8 +18,101 @@ func Get(ctx context.Context, dbc dbc, key string) (goku.KV, error) {
 	return lookupWhere(ctx, dbc, "`key`=? and deleted_ref is null", key)
 }
 
-func List(ctx context.Context, dbc dbc, prefix string, fn func(goku.KV) error) error {
-	return scanWhere(ctx, dbc, fn, "`key` like ? and deleted_ref is null", prefix+"%")
+// GetMany returns the key-values for the keys in the same order as the keys. A zero KV is
+// returned for keys that are not found.
+func GetMany(ctx context.Context, dbc dbc, keys []string) ([]goku.KV, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		args = append(args, key)
+	}
+
+	where := "`key` in (?" + strings.Repeat(",?", len(keys)-1) + ") and deleted_ref is null"
+	kvl, err := listWhere(ctx, dbc, where, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]goku.KV, len(kvl))
+	for _, kv := range kvl {
+		found[kv.Key] = kv
+	}
+
+	res := make([]goku.KV, 0, len(keys))
+	for _, key := range keys {
+		res = append(res, found[key])
+	}
+
+	return res, nil
+}
+
+// List calls fn with all the key-values with keys matching the prefix ordered by key and
+// filtered by the options.
+func List(ctx context.Context, dbc dbc, prefix string, opts goku.ListOptions, fn func(goku.KV) error) error {
+	where := "`key` like ? escape '!' and deleted_ref is null"
+	args := []interface{}{likePrefix(prefix)}
+
+	lt, gt := "<", ">"
+	if opts.Reverse {
+		lt, gt = gt, lt
+	}
+
+	if opts.StartAfter != "" {
+		where += " and `key`" + gt + "?"
+		args = append(args, opts.StartAfter)
+	}
+
+	if opts.EndKey != "" {
+		where += " and `key`" + lt + "?"
+		args = append(args, opts.EndKey)
+	}
+
+	where += " order by `key`"
+	if opts.Reverse {
+		where += " desc"
+	}
+
+	if opts.Limit > 0 {
+		where += " limit ?"
+		args = append(args, opts.Limit)
+	}
+
+	if opts.KeysOnly {
+		return scanKeysWhere(ctx, dbc, fn, where, args...)
+	}
+
+	return scanWhere(ctx, dbc, fn, where, args...)
+}
+
+// selectKeysPrefix selects the same columns as selectPrefix, except for values.
+const selectKeysPrefix = "select `key`, null, `version`, `created_ref`, `updated_ref`, `deleted_ref`, `lease_id` " +
+	"from data where "
+
+// scanKeysWhere queries the data table without values with the provided where clause and
+// calls fn with the results one row at a time.
+func scanKeysWhere(ctx context.Context, dbc dbc, fn func(goku.KV) error, where string, args ...interface{}) error {
+	rows, err := dbc.QueryContext(ctx, selectKeysPrefix+where, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		kv, err := scan(rows)
+		if err != nil {
+			return err
+		}
+
+		err = fn(kv)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
 }
 
 type SetReq struct {
@@ -26,22 +121,190 @@ type SetReq struct {
 
 	// Options
 	LeaseID     int64     // Zero creates a new lease on create or updates existing on update.
-	ExpiresAt   time.Time // Zero is infinite
+	ExpiresAt   time.Time // Zero is infinite, unless LeaseID is provided which leaves its expiry as is.
 	PrevVersion int64     // Zero ignores check
 	CreateOnly  bool      // Zero ignores check
 }
 
 func Set(ctx context.Context, dbc *sql.DB, req SetReq) error {
-	if len(req.Key) == 0 || len(req.Key) >= 256 || strings.Contains(req.Key, "%") {
-		return goku.ErrInvalidKey
+	tx, err := dbc.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = setTx(ctx, tx, getDriver(dbc), req)
+	if err != nil {
+		return err
 	}
 
+	defer notifier.Notify()
+
+	return tx.Commit()
+}
+
+// SetMany creates or updates all the key-values in a single transaction.
+func SetMany(ctx context.Context, dbc *sql.DB, reqs []SetReq) error {
 	tx, err := dbc.Begin()
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
+	for _, req := range reqs {
+		err := setTx(ctx, tx, getDriver(dbc), req)
+		if err != nil {
+			return err
+		}
+	}
+
+	defer notifier.Notify()
+
+	return tx.Commit()
+}
+
+type CreateReq struct {
+	Prefix string
+	Value  []byte
+
+	// Options
+	LeaseID   int64     // Zero creates a new lease.
+	ExpiresAt time.Time // Zero is infinite
+}
+
+// Create creates a key-value with a key consisting of the prefix and the next zero-padded sequence number
+// of the prefix and returns the key. Concurrent creates of the same prefix are serialised, so keys are
+// created (and committed) in sequence order.
+func Create(ctx context.Context, dbc *sql.DB, req CreateReq) (string, error) {
+	tx, err := dbc.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	seq, err := nextSeq(ctx, tx, getDriver(dbc), req.Prefix)
+	if err != nil {
+		return "", err
+	}
+
+	key := req.Prefix + fmt.Sprintf("%020d", seq)
+
+	err = setTx(ctx, tx, getDriver(dbc), SetReq{
+		Key:        key,
+		Value:      req.Value,
+		LeaseID:    req.LeaseID,
+		ExpiresAt:  req.ExpiresAt,
+		CreateOnly: true,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	defer notifier.Notify()
+
+	return key, tx.Commit()
+}
+
+// nextSeq increments and returns the sequence number of the prefix. The upsert locks the
+// sequence row until the transaction completes, including the first sequence number of a new prefix.
+func nextSeq(ctx context.Context, tx *sql.Tx, d Driver, prefix string) (int64, error) {
+	_, err := tx.ExecContext(ctx, d.IncrementSeq(), prefix)
+	if err != nil {
+		return 0, errors.Wrap(err, "increment sequence")
+	}
+
+	var seq int64
+	err = tx.QueryRowContext(ctx, "select seq from sequences where prefix=?", prefix).Scan(&seq)
+	if err != nil {
+		return 0, errors.Wrap(err, "select sequence")
+	}
+
+	return seq, nil
+}
+
+const incrementAttempts = 3
+
+// Increment adds the delta to the integer value of the key-value and returns the new value. Values are
+// stored as decimal strings. Non-existent (or deleted) key-values are created with an initial value
+// of delta. The lease (and its expiry) of existing key-values is maintained.
+//
+// Concurrent increments of a non-existent key race to create it, the losers are retried.
+func Increment(ctx context.Context, dbc *sql.DB, key string, delta int64) (int64, error) {
+	var err error
+	for i := 0; i < incrementAttempts; i++ {
+		var next int64
+		next, err = incrementOnce(ctx, dbc, key, delta)
+		if errors.Is(err, goku.ErrUpdateRace) {
+			continue
+		}
+
+		return next, err
+	}
+
+	return 0, err
+}
+
+func incrementOnce(ctx context.Context, dbc *sql.DB, key string, delta int64) (int64, error) {
+	tx, err := dbc.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var (
+		val       int64
+		leaseID   int64
+		expiresAt time.Time
+	)
+	kv, err := lookupLocked(ctx, tx, getDriver(dbc), "`key`=? and deleted_ref is null", key)
+	if errors.Is(err, goku.ErrNotFound) {
+		// Start at zero
+	} else if err != nil {
+		return 0, err
+	} else {
+		if len(kv.Value) > 0 {
+			val, err = strconv.ParseInt(string(kv.Value), 10, 64)
+			if err != nil {
+				return 0, errors.Wrap(goku.ErrNotInteger, "", j.KV("key", key))
+			}
+		}
+
+		l, err := GetLease(ctx, tx, kv.LeaseID)
+		if err != nil {
+			return 0, err
+		}
+
+		leaseID = l.ID
+		expiresAt = l.ExpiresAt
+	}
+
+	next := val + delta
+	if (delta > 0 && next < val) || (delta < 0 && next > val) {
+		return 0, errors.New("increment overflow", j.KV("key", key))
+	}
+
+	err = setTx(ctx, tx, getDriver(dbc), SetReq{
+		Key:         key,
+		Value:       []byte(strconv.FormatInt(next, 10)),
+		LeaseID:     leaseID,
+		ExpiresAt:   expiresAt,
+		PrevVersion: kv.Version,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	defer notifier.Notify()
+
+	return next, tx.Commit()
+}
+
+// setTx creates or updates the key-value in the provided transaction.
+func setTx(ctx context.Context, tx *sql.Tx, d Driver, req SetReq) error {
+	if len(req.Key) == 0 || len(req.Key) >= 256 || strings.Contains(req.Key, "%") {
+		return goku.ErrInvalidKey
+	}
+
 	// Step 0: Lookup existing row.
 	var (
 		leaseID   int64
@@ -70,20 +333,15 @@ func Set(ctx context.Context, dbc *sql.DB, req SetReq) error {
 		leaseID = req.LeaseID
 	}
 
-	// Step1: Insert event
-	ref, err := insertEvent(ctx, tx, req.Key, goku.EventTypeSet, req.Value)
-	if err != nil {
-		return err
-	}
-
-	// Step2: Insert or update the lease.
+	// Step1: Insert or update the lease.
 	if leaseID == 0 {
-		res, err := tx.ExecContext(ctx, "insert into leases "+
-			"set version=1, expires_at=?", toNullTime(req.ExpiresAt))
+		leaseID, err = insertLease(ctx, tx, d, req.ExpiresAt)
 		if err != nil {
 			return err
 		}
-		leaseID, err = res.LastInsertId()
+	} else if req.LeaseID != 0 && req.ExpiresAt.IsZero() {
+		// Leave the expiry of the requested lease as is.
+		err := touchLeaseTx(ctx, tx, leaseID)
 		if err != nil {
 			return err
 		}
@@ -94,6 +352,12 @@ func Set(ctx context.Context, dbc *sql.DB, req SetReq) error {
 		}
 	}
 
+	// Step2: Insert event
+	ref, err := insertEvent(ctx, tx, d, req.Key, goku.EventTypeSet, req.Value, kv.Version+1, leaseID)
+	if err != nil {
+		return err
+	}
+
 	// Use event ref if we need to set a new created ref
 	if createRef == 0 {
 		createRef = ref
@@ -110,7 +374,7 @@ func Set(ctx context.Context, dbc *sql.DB, req SetReq) error {
 		}
 	} else {
 		_, err := tx.ExecContext(ctx, "insert into data "+
-			"set `key`=?, value=?, version=1, created_ref=?, updated_ref=?, lease_id=?",
+			"(`key`, value, version, created_ref, updated_ref, lease_id) values (?, ?, 1, ?, ?, ?)",
 			req.Key, req.Value, createRef, ref, leaseID)
 		if isDuplicateKeyErr(err) {
 			return goku.ErrUpdateRace
@@ -119,57 +383,143 @@ func Set(ctx context.Context, dbc *sql.DB, req SetReq) error {
 		}
 	}
 
+	return nil
+}
+
+type DeleteReq struct {
+	Key string
+
+	// Options
+	PrevVersion int64 // Zero ignores check
+	LeaseID     int64 // Zero ignores check
+}
+
+// Delete soft-deletes the key-value and returns it as it was before it was deleted.
+func Delete(ctx context.Context, dbc *sql.DB, req DeleteReq) (goku.KV, error) {
+	tx, err := dbc.Begin()
+	if err != nil {
+		return goku.KV{}, err
+	}
+	defer tx.Rollback()
+
+	prev, err := deleteTx(ctx, tx, getDriver(dbc), req)
+	if err != nil {
+		return goku.KV{}, err
+	}
+
 	defer notifier.Notify()
 
-	return tx.Commit()
+	return prev, tx.Commit()
+}
+
+const defaultDeleteBatch = 1000
+
+// DeletePrefix soft-deletes all key-values with keys matching the prefix in batches and returns the
+// number of deleted key-values. Each batch is deleted in its own transaction.
+func DeletePrefix(ctx context.Context, dbc *sql.DB, prefix string, opts goku.DeletePrefixOptions) (int64, error) {
+	if prefix == "" {
+		return 0, errors.Wrap(goku.ErrInvalidKey, "empty prefix")
+	}
+
+	batch := opts.BatchSize
+	if batch <= 0 {
+		batch = defaultDeleteBatch
+	}
+
+	var total int64
+	for {
+		n, err := deletePrefixBatch(ctx, dbc, prefix, batch)
+		if err != nil {
+			return total, err
+		}
+
+		total += n
+
+		if n < batch {
+			return total, nil
+		}
+	}
 }
 
-func Delete(ctx context.Context, dbc *sql.DB, key string) error {
+// deletePrefixBatch soft-deletes the next batch of key-values matching the prefix in a transaction.
+func deletePrefixBatch(ctx context.Context, dbc *sql.DB, prefix string, batch int64) (int64, error) {
 	tx, err := dbc.Begin()
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer tx.Rollback()
 
-	kv, err := lookupWhere(ctx, tx, "`key`=?", key)
+	kvl, err := listWhere(ctx, tx, "`key` like ? escape '!' and deleted_ref is null "+
+		"order by `key` limit ?"+getDriver(dbc).ForUpdate(), likePrefix(prefix), batch)
 	if err != nil {
-		return err
+		return 0, err
+	}
+
+	for _, kv := range kvl {
+		_, err := deleteTx(ctx, tx, getDriver(dbc), DeleteReq{Key: kv.Key})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if len(kvl) > 0 {
+		defer notifier.Notify()
+	}
+
+	return int64(len(kvl)), tx.Commit()
+}
+
+// deleteTx soft-deletes the key-value in the provided transaction and returns it as it was before it was deleted.
+func deleteTx(ctx context.Context, tx *sql.Tx, d Driver, req DeleteReq) (goku.KV, error) {
+	kv, err := lookupWhere(ctx, tx, "`key`=?", req.Key)
+	if err != nil {
+		return goku.KV{}, err
 	}
 
 	if kv.DeletedRef != 0 {
-		return errors.Wrap(goku.ErrNotFound, "")
+		return goku.KV{}, errors.Wrap(goku.ErrNotFound, "")
+	} else if req.PrevVersion > 0 && kv.Version != req.PrevVersion {
+		return goku.KV{}, errors.Wrap(goku.ErrConditional, "previous version mismatch")
+	} else if req.LeaseID > 0 && kv.LeaseID != req.LeaseID {
+		return goku.KV{}, errors.Wrap(goku.ErrConditional, "lease mismatch")
 	}
 
-	ref, err := insertEvent(ctx, tx, key, goku.EventTypeDelete, nil)
+	ref, err := insertEvent(ctx, tx, d, req.Key, goku.EventTypeDelete, nil, kv.Version+1, kv.LeaseID)
 	if err != nil {
-		return err
+		return goku.KV{}, err
 	}
 
 	err = execOne(ctx, tx, "update data "+
 		"set value=null, version=?+1, updated_ref=?, deleted_ref=? "+
 		"where `key`=? and version=?",
-		kv.Version, ref, ref, key, kv.Version)
+		kv.Version, ref, ref, req.Key, kv.Version)
 	if err != nil {
-		return err
+		return goku.KV{}, err
 	}
 
-	defer notifier.Notify()
+	return kv, nil
+}
 
-	return tx.Commit()
+// insertEvent inserts an event with the value (metadata) and the version and lease id of the key-value after the event.
+func insertEvent(ctx context.Context, tx *sql.Tx, d Driver, key string, typ reflex.EventType, metadata []byte,
+	version, leaseID int64) (int64, error) {
+
+	return insertID(ctx, tx, d, "insert into events "+
+		"(`key`, `type`, timestamp, metadata, version, lease_id) values (?, ?, ?, ?, ?, ?)",
+		key, typ, time.Now().UTC(), metadata, version, leaseID)
 }
 
-func insertEvent(ctx context.Context, tx *sql.Tx, key string, typ reflex.EventType, metadata []byte) (int64, error) {
-	res, err := tx.ExecContext(ctx, "insert into events "+
-		"set `key`=?, `type`=?, timestamp=now(), metadata=?", key, typ, metadata)
-	if err != nil {
-		return 0, err
-	}
-	id, err := res.LastInsertId()
+// lookupLocked returns the key-value matching the where clause and locks its row until the transaction
+// completes. Missing rows are not locked, since locking reads of missing rows take gap locks which
+// deadlock concurrent transactions creating the same key. The unique key index serialises their
+// inserts instead, the losers fail with goku.ErrUpdateRace.
+func lookupLocked(ctx context.Context, tx *sql.Tx, d Driver, where string, args ...interface{}) (goku.KV, error) {
+	_, err := lookupWhere(ctx, tx, where, args...)
 	if err != nil {
-		return 0, err
+		return goku.KV{}, err
 	}
 
-	return id, nil
+	return lookupWhere(ctx, tx, where+d.ForUpdate(), args...)
 }
 
 func execOne(ctx context.Context, dbc dbc, q string, args ...interface{}) error {
@@ -188,26 +538,9 @@ func execOne(ctx context.Context, dbc dbc, q string, args ...interface{}) error
 	return nil
 }
 
-const errDupEntry = 1062
-
-// IsDuplicateErrForKey returns true if the provided error is a mysql ER_DUP_ENTRY
-// error that conflicts with the specified unique index or primary key.
-func isDuplicateKeyErr(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	me := new(mysql.MySQLError)
-	if !errors.As(err, &me) {
-		return false
-	}
-
-	return me.Number == errDupEntry
-}
-
 func toNullTime(t time.Time) sql.NullTime {
 	return sql.NullTime{
-		Time:  t,
+		Time:  t.UTC(),
 		Valid: !t.IsZero(),
 	}
 }
@@ -0,0 +1,146 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"sync"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/luno/jettison/errors"
+)
+
+// Driver defines the sql dialect differences of a storage backend. Queries of this package
+// are written in a portable subset of mysql; the driver provides the rest.
+//
+// The mysql driver is registered by default, other backends register their drivers
+// via RegisterDriver, see db/sqlite.
+type Driver interface {
+	// ForUpdate returns the clause appended to select queries to lock the selected rows
+	// until the transaction completes. It is empty for databases that serialise write transactions.
+	ForUpdate() string
+
+	// IsDuplicateKeyErr returns true if the error is a primary key or unique index violation.
+	IsDuplicateKeyErr(err error) bool
+
+	// ReturningID returns the clause appended to insert statements to return the id of the inserted
+	// row. It is empty for databases that provide the id via sql.Result.LastInsertId.
+	ReturningID() string
+
+	// IncrementSeq returns the statement inserting the sequence of the prefix (the argument) starting
+	// at 1, or incrementing it if it already exists.
+	IncrementSeq() string
+
+	// CompactEvents returns the statement nulling the metadata of the events superseded by later
+	// events of the same key with ids between the arguments (inclusive).
+	CompactEvents() string
+
+	// DeleteLimit returns the statement deleting the rows of the table matching the where clause.
+	// The number of rows deleted is limited by the last argument. The pk column uniquely identifies
+	// rows of the table.
+	DeleteLimit(table, pk, where string) string
+}
+
+var (
+	driversMu sync.RWMutex
+	drivers   = map[reflect.Type]Driver{
+		reflect.TypeOf(&mysql.MySQLDriver{}): mysqlDriver{},
+	}
+)
+
+// RegisterDriver registers the storage driver for connections opened with the database/sql driver.
+func RegisterDriver(sqlDriver driver.Driver, d Driver) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+
+	drivers[reflect.TypeOf(sqlDriver)] = d
+}
+
+// getDriver returns the storage driver of the connection. It defaults to mysql.
+func getDriver(dbc *sql.DB) Driver {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+
+	if d, ok := drivers[reflect.TypeOf(dbc.Driver())]; ok {
+		return d
+	}
+
+	return mysqlDriver{}
+}
+
+// isDuplicateKeyErr returns true if the error is a duplicate key error of any registered driver.
+// Drivers return distinct error types, so this doesn't require the driver of the connection.
+func isDuplicateKeyErr(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+
+	for _, d := range drivers {
+		if d.IsDuplicateKeyErr(err) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// insertID executes the insert statement and returns the id of the inserted row.
+func insertID(ctx context.Context, dbc dbc, d Driver, q string, args ...interface{}) (int64, error) {
+	if returning := d.ReturningID(); returning != "" {
+		var id int64
+		err := dbc.QueryRowContext(ctx, q+returning, args...).Scan(&id)
+		if err != nil {
+			return 0, err
+		}
+
+		return id, nil
+	}
+
+	res, err := dbc.ExecContext(ctx, q, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+type mysqlDriver struct{}
+
+func (mysqlDriver) ForUpdate() string {
+	return " for update"
+}
+
+func (mysqlDriver) ReturningID() string {
+	return ""
+}
+
+func (mysqlDriver) IncrementSeq() string {
+	return "insert into sequences (prefix, seq) values (?, 1) on duplicate key update seq=seq+1"
+}
+
+func (mysqlDriver) CompactEvents() string {
+	return "update events e " +
+		"join events n on n.`key`=e.`key` and n.id>e.id and n.`type`!=0 " +
+		"set e.metadata=null " +
+		"where n.id>=? and n.id<=? and e.metadata is not null"
+}
+
+func (mysqlDriver) DeleteLimit(table, _, where string) string {
+	return "delete from " + table + " where " + where + " limit ?"
+}
+
+const errDupEntry = 1062
+
+// IsDuplicateKeyErr returns true if the provided error is a mysql ER_DUP_ENTRY error.
+func (mysqlDriver) IsDuplicateKeyErr(err error) bool {
+	me := new(mysql.MySQLError)
+	if !errors.As(err, &me) {
+		return false
+	}
+
+	return me.Number == errDupEntry
+}
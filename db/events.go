@@ -1,36 +1,137 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"strconv"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/jettison/log"
 	"github.com/luno/reflex"
 	"github.com/luno/reflex/rsql"
 )
 
-var events = rsql.NewEventsTable("events",
-	rsql.WithEventMetadataField("metadata"),
-	rsql.WithEventTimeField("timestamp"),
-	rsql.WithEventForeignIDField("`key`"),
-	rsql.WithEventsNotifier(notifier))
+var events = NewEventsTable(notifier)
 
-var notifier = new(memNotifier) // TODO(corver): Provide a way to configure other notifiers.
+// notifier is notified by all writes in this process. It is the default events table notifier.
+var notifier = new(memNotifier)
+
+// NewEventsTable returns a new goku events table using the provided notifier to
+// trigger stream clients when new events are available. Note that gaps
+// in tables other than the default are not filled by FillGaps.
+// See PrefixStreamer for streaming events filtered by key prefix.
+//
+// Events are loaded with the portable loader of this package instead of the default
+// rsql loader which uses mysql specific syntax for stream lag.
+func NewEventsTable(n rsql.EventsNotifier) *rsql.EventsTable {
+	return rsql.NewEventsTable("events",
+		rsql.WithEventMetadataField("metadata"),
+		rsql.WithEventTimeField("timestamp"),
+		rsql.WithEventForeignIDField("`key`"),
+		rsql.WithEventsLoader(loadNextEvents),
+		rsql.WithEventsNotifier(n))
+}
 
 // ToStream returns a reflex stream for deposit events.
 func ToStream(dbc *sql.DB) reflex.StreamFunc {
-	return events.ToStream(dbc)
+	return TableToStream(events, dbc)
+}
+
+// TableToStream returns a reflex stream for the events table. It returns goku.ErrCompacted
+// when streaming after a compacted event, including streams from the start (with an empty after)
+// once the events table was compacted, since the compacted events do not reflect all updates.
+func TableToStream(table *rsql.EventsTable, dbc *sql.DB) reflex.StreamFunc {
+	return withCompaction(dbc, table.ToStream(dbc))
+}
+
+// withCompaction returns the stream function with the compaction behaviour of TableToStream.
+func withCompaction(dbc *sql.DB, sFn reflex.StreamFunc) reflex.StreamFunc {
+	return func(ctx context.Context, after string, opts ...reflex.StreamOption) (reflex.StreamClient, error) {
+		var o reflex.StreamOptions
+		for _, opt := range opts {
+			opt(&o)
+		}
+
+		err := checkStreamCompacted(ctx, dbc, after, o)
+		if err != nil {
+			return nil, err
+		}
+
+		return sFn(ctx, after, opts...)
+	}
+}
+
+// checkStreamCompacted returns goku.ErrCompacted if streaming after the cursor would skip
+// compacted events, see TableToStream.
+func checkStreamCompacted(ctx context.Context, dbc *sql.DB, after string, o reflex.StreamOptions) error {
+	if o.StreamFromHead {
+		// Compaction doesn't apply.
+		return nil
+	}
+
+	var ref int64
+	if after != "" {
+		var err error
+		ref, err = strconv.ParseInt(after, 10, 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	return checkCompacted(ctx, dbc, ref)
 }
 
 // FillGaps registers the default reflex gap filler for the deposit events table.
 func FillGaps(dbc *sql.DB) {
-	rsql.FillGaps(dbc, events)
+	fillGaps(dbc, events)
+}
+
+// fillGaps registers a gap filler for the table. Mysql connections use the default reflex
+// gap filler. The default depends on read uncommitted isolation and mysql syntax, so other
+// drivers insert noop events with the missing ids directly; the insert blocks while an
+// uncommitted event with the id exists and fails with a duplicate key error once it is committed.
+func fillGaps(dbc *sql.DB, table *rsql.EventsTable) {
+	if _, ok := getDriver(dbc).(mysqlDriver); ok {
+		rsql.FillGaps(dbc, table)
+		return
+	}
+
+	table.ListenGaps(func(gap rsql.Gap) {
+		ctx := context.Background()
+		err := fillGap(ctx, dbc, gap)
+		if err != nil {
+			// ReturnNoErr: The gap is detected and filled again by the next stream query.
+			log.Error(ctx, err)
+		}
+	})
+}
+
+// fillGap inserts noop events with the ids missing in the gap. The insert blocks while an
+// uncommitted event with the id exists and fails with a duplicate key error once it is committed.
+func fillGap(ctx context.Context, dbc *sql.DB, gap rsql.Gap) error {
+	for id := gap.Prev + 1; id < gap.Next; id++ {
+		_, err := dbc.ExecContext(ctx, "insert into events "+
+			"(id, `key`, `type`, timestamp) values (?, '0', 0, ?)", id, time.Now().UTC())
+		if isDuplicateKeyErr(err) {
+			// The event was committed, that's ok.
+			continue
+		} else if err != nil {
+			return errors.Wrap(err, "fill gap", j.KV("id", id))
+		}
+	}
+
+	return nil
 }
 
 // CleanCache clears the cache after testing to clear test artifacts.
 func CleanCache(t *testing.T) {
 	t.Cleanup(func() {
-		events = events.Clone()
+		// Note that rsql Clone doesn't retain the events loader.
+		events = NewEventsTable(notifier)
 	})
 }
 
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const gcBatch = 1000
+
+// DeleteTombstones hard-deletes soft-deleted key-values (tombstones) deleted before
+// the cutoff. It returns the number of rows deleted.
+//
+// Note that the version of a key-value recreated after its tombstone is deleted restarts at 1.
+func DeleteTombstones(ctx context.Context, dbc *sql.DB, cutoff time.Time) (int64, error) {
+	ref, err := getMaxEventIDBefore(ctx, dbc, cutoff)
+	if err != nil {
+		return 0, err
+	} else if ref == 0 {
+		return 0, nil
+	}
+
+	q := getDriver(dbc).DeleteLimit("data", "`key`", "deleted_ref is not null and deleted_ref<=?")
+
+	return deleteBatches(ctx, dbc, tombstonesReclaimedCounter, q, ref, gcBatch)
+}
+
+// DeleteExpiredLeases deletes expired leases without any (non-deleted) keys.
+// It returns the number of rows deleted.
+//
+// Note that leases that are not expired are never deleted, even if all their keys are deleted,
+// since granted leases may not have keys yet. Expire them via ExpireLease (or an expiry and
+// ExpireLeasesForever) to reclaim them.
+func DeleteExpiredLeases(ctx context.Context, dbc *sql.DB) (int64, error) {
+	q := getDriver(dbc).DeleteLimit("leases", "id", "expired=true and not exists "+
+		"(select 1 from data where data.lease_id=leases.id and data.deleted_ref is null)")
+
+	return deleteBatches(ctx, dbc, leasesReclaimedCounter, q, gcBatch)
+}
+
+// deleteBatches executes the batched delete query until less than a batch is deleted. It
+// returns the total rows deleted.
+func deleteBatches(ctx context.Context, dbc *sql.DB, counter prometheus.Counter, q string, args ...interface{}) (int64, error) {
+	var total int64
+	for {
+		res, err := dbc.ExecContext(ctx, q, args...)
+		if err != nil {
+			return total, err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+
+		counter.Add(float64(n))
+		total += n
+
+		if n < gcBatch {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/jtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteTombstones(t *testing.T) {
+	ctx := context.Background()
+	dbc := ConnectForTesting(t)
+
+	for _, key := range []string{"key1", "key2", "key3"} {
+		err := Set(ctx, dbc, SetReq{Key: key})
+		jtest.RequireNil(t, err)
+	}
+
+	_, err := Delete(ctx, dbc, DeleteReq{Key: "key1"})
+	jtest.RequireNil(t, err)
+
+	kv, err := Get(ctx, dbc, "key2")
+	jtest.RequireNil(t, err)
+	err = ExpireLease(ctx, dbc, kv.LeaseID)
+	jtest.RequireNil(t, err)
+
+	n, err := DeleteTombstones(ctx, dbc, time.Now().Add(-time.Hour))
+	jtest.RequireNil(t, err)
+	require.Zero(t, n)
+
+	n, err = DeleteTombstones(ctx, dbc, time.Now().Add(time.Hour))
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(2), n)
+
+	n, err = DeleteExpiredLeases(ctx, dbc)
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(1), n)
+
+	var count int
+	err = dbc.QueryRow("select count(*) from data").Scan(&count)
+	jtest.RequireNil(t, err)
+	require.Equal(t, 1, count)
+
+	// Recreated keys restart at version 1.
+	err = Set(ctx, dbc, SetReq{Key: "key1"})
+	jtest.RequireNil(t, err)
+
+	kv, err = Get(ctx, dbc, "key1")
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(1), kv.Version)
+
+	at, err := GetAt(ctx, dbc, "key1", kv.UpdatedRef)
+	jtest.RequireNil(t, err)
+	require.Equal(t, kv.Version, at.Version)
+
+	err = ExpireLease(ctx, dbc, 2)
+	jtest.Require(t, goku.ErrLeaseNotFound, err)
+}
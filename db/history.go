@@ -0,0 +1,169 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/errors"
+)
+
+// GetAt returns the key-value for the given key as it was after the event with id ref.
+// It is reconstructed from the events table, so LeaseID is not populated. It returns
+// goku.ErrCompacted if the ref is before the event log compaction ref.
+func GetAt(ctx context.Context, dbc dbc, key string, ref int64) (goku.KV, error) {
+	err := checkCompacted(ctx, dbc, ref)
+	if err != nil {
+		return goku.KV{}, err
+	}
+
+	var res goku.KV
+	fn := func(kv goku.KV) error {
+		res = kv
+		return nil
+	}
+
+	err = foldEventsWhere(ctx, dbc, fn, "`key`=? and id<=?", key, ref)
+	if err != nil {
+		return goku.KV{}, err
+	}
+
+	if res.Version == 0 {
+		return goku.KV{}, errors.Wrap(goku.ErrNotFound, "")
+	}
+
+	return res, nil
+}
+
+// ListAt calls fn with all the key-values with keys matching the prefix ordered by key as they
+// were after the event with id ref. They are reconstructed from the events table,
+// so LeaseID is not populated.
+func ListAt(ctx context.Context, dbc dbc, prefix string, ref int64, fn func(goku.KV) error) error {
+	err := checkCompacted(ctx, dbc, ref)
+	if err != nil {
+		return err
+	}
+
+	return foldEventsWhere(ctx, dbc, fn, "`key` like ? escape '!' and id<=?", likePrefix(prefix), ref)
+}
+
+// foldEventsWhere queries the events table with the provided where clause and folds
+// the events of each key into its key-value state. It calls fn with the resulting
+// key-values ordered by key, excluding deleted key-values.
+func foldEventsWhere(ctx context.Context, dbc dbc, fn func(goku.KV) error,
+	where string, args ...interface{}) error {
+
+	rows, err := dbc.QueryContext(ctx, "select id, `key`, `type`, metadata, version from events "+
+		"where `type`!=0 and "+where+" order by `key`, id", args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var kv goku.KV
+	maybeCall := func() error {
+		if kv.Version == 0 || kv.DeletedRef != 0 {
+			return nil
+		}
+		return fn(kv)
+	}
+
+	for rows.Next() {
+		var (
+			id       int64
+			key      string
+			typ      goku.EventType
+			metadata []byte
+			version  sql.NullInt64
+		)
+		err := rows.Scan(&id, &key, &typ, &metadata, &version)
+		if err != nil {
+			return err
+		}
+
+		if key != kv.Key {
+			if err := maybeCall(); err != nil {
+				return err
+			}
+			kv = goku.KV{Key: key}
+		}
+
+		applyEvent(&kv, id, typ, metadata, version)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return maybeCall()
+}
+
+// applyEvent updates the key-value with the event as the data table would be updated. The version
+// is the key-value version stored with the event, since versions restart when tombstones are deleted.
+// Events without stored versions increment the version.
+func applyEvent(kv *goku.KV, id int64, typ goku.EventType, value []byte, version sql.NullInt64) {
+	if version.Valid {
+		kv.Version = version.Int64
+	} else {
+		kv.Version++
+	}
+	kv.UpdatedRef = id
+
+	switch typ {
+	case goku.EventTypeSet:
+		if kv.CreatedRef == 0 || kv.DeletedRef != 0 {
+			kv.CreatedRef = id
+		}
+		kv.DeletedRef = 0
+		kv.Value = value
+	case goku.EventTypeDelete, goku.EventTypeExpire:
+		kv.DeletedRef = id
+		kv.Value = nil
+	}
+}
+
+// History calls fn with the revisions of the given key ordered by ref and filtered by the options.
+// Note that values of revisions superseded before the event log compaction ref are empty.
+func History(ctx context.Context, dbc dbc, key string, opts goku.HistoryOptions, fn func(goku.Revision) error) error {
+	q := "select id, `type`, timestamp, metadata from events where `key`=? and `type`!=0"
+	args := []interface{}{key}
+
+	if opts.AfterRef > 0 && opts.Reverse {
+		q += " and id<?"
+		args = append(args, opts.AfterRef)
+	} else if opts.AfterRef > 0 {
+		q += " and id>?"
+		args = append(args, opts.AfterRef)
+	}
+
+	q += " order by id"
+	if opts.Reverse {
+		q += " desc"
+	}
+
+	if opts.Limit > 0 {
+		q += " limit ?"
+		args = append(args, opts.Limit)
+	}
+
+	rows, err := dbc.QueryContext(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		r := goku.Revision{Key: key}
+		err := rows.Scan(&r.Ref, &r.Type, &r.Timestamp, &r.Value)
+		if err != nil {
+			return err
+		}
+
+		err = fn(r)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -51,7 +51,7 @@ func ExpireLease(ctx context.Context, dbc *sql.DB, leaseID int64) error {
 	}
 
 	for _, kv := range kvl {
-		ref, err := insertEvent(ctx, tx, kv.Key, goku.EventTypeExpire, nil)
+		ref, err := insertEvent(ctx, tx, getDriver(dbc), kv.Key, goku.EventTypeExpire, nil, kv.Version+1, kv.LeaseID)
 		if err != nil {
 			return err
 		}
@@ -85,18 +85,80 @@ func updateLeaseTx(ctx context.Context, tx *sql.Tx, leaseID int64, expiresAt tim
 	return nil
 }
 
+// touchLeaseTx increments the version of the (non-expired) lease without changing its expiry.
+func touchLeaseTx(ctx context.Context, tx *sql.Tx, leaseID int64) error {
+	res, err := tx.ExecContext(ctx, "update leases set version=version+1 where id=? and expired=false", leaseID)
+	if err != nil {
+		return errors.Wrap(err, "touch lease tx")
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return errors.Wrap(goku.ErrLeaseNotFound, "")
+	}
+
+	return nil
+}
+
 func ListLeasesToExpire(ctx context.Context, dbc *sql.DB, cutoff time.Time) ([]Lease, error) {
-	return listLeasesWhere(ctx, dbc, "expires_at <= ?", cutoff)
+	return listLeasesWhere(ctx, dbc, "expires_at <= ?", cutoff.UTC())
+}
+
+type Lease = goku.Lease
+
+// GrantLease inserts a new lease and returns its id.
+func GrantLease(ctx context.Context, dbc *sql.DB, expiresAt time.Time) (int64, error) {
+	return insertLease(ctx, dbc, getDriver(dbc), expiresAt)
+}
+
+// GetLease returns the lease (expired or not) for the given id.
+func GetLease(ctx context.Context, dbc dbc, leaseID int64) (Lease, error) {
+	ll, err := listLeasesWhere(ctx, dbc, "id=?", leaseID)
+	if err != nil {
+		return Lease{}, err
+	} else if len(ll) == 0 {
+		return Lease{}, errors.Wrap(goku.ErrLeaseNotFound, "")
+	}
+
+	return ll[0], nil
+}
+
+// ListLeaseKeys calls fn with all the (non-deleted) key-values associated with the given lease.
+func ListLeaseKeys(ctx context.Context, dbc dbc, leaseID int64, fn func(goku.KV) error) error {
+	return scanWhere(ctx, dbc, fn, "lease_id=? and deleted_ref is null order by `key`", leaseID)
+}
+
+// ListLeases returns the leases ordered by id filtered by the options.
+func ListLeases(ctx context.Context, dbc *sql.DB, opts goku.ListLeasesOptions) ([]Lease, error) {
+	where := "id>?"
+	args := []interface{}{opts.AfterID}
+
+	if !opts.IncludeExpired {
+		where += " and expired=false"
+	}
+
+	if !opts.ExpiresBefore.IsZero() {
+		where += " and expires_at<?"
+		args = append(args, opts.ExpiresBefore.UTC())
+	}
+
+	where += " order by id"
+
+	if opts.Limit > 0 {
+		where += " limit ?"
+		args = append(args, opts.Limit)
+	}
+
+	return listLeasesWhere(ctx, dbc, where, args...)
 }
 
-type Lease struct {
-	ID        int64
-	ExpiresAt time.Time
-	Version   int64
-	Expired   bool
+func insertLease(ctx context.Context, dbc dbc, d Driver, expiresAt time.Time) (int64, error) {
+	return insertID(ctx, dbc, d, "insert into leases "+
+		"(version, expires_at) values (1, ?)", toNullTime(expiresAt))
 }
 
-func listLeasesWhere(ctx context.Context, dbc *sql.DB, where string, args ...interface{}) ([]Lease, error) {
+func listLeasesWhere(ctx context.Context, dbc dbc, where string, args ...interface{}) ([]Lease, error) {
 	rows, err := dbc.QueryContext(ctx, "select id, version, expires_at, expired "+
 		"from leases where "+where, args...)
 	if err != nil {
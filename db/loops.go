@@ -65,3 +65,27 @@ func expireLeasesOnce(ctx context.Context, dbc *sql.DB, cutoff time.Time, sleepT
 
 	return nil
 }
+
+// DeleteTombstonesForever continuously deletes tombstones (soft-deleted key-values) older than
+// the retention period and expired leases without keys with a polling period of 1 minute.
+func DeleteTombstonesForever(dbc *sql.DB, retention time.Duration) {
+	const period = time.Minute
+
+	for {
+		ctx := context.Background()
+
+		_, err := DeleteTombstones(ctx, dbc, time.Now().Add(-retention))
+		if err != nil {
+			// ReturnNoErr: Log and try again next period.
+			log.Error(ctx, errors.Wrap(err, "delete tombstones"))
+		}
+
+		_, err = DeleteExpiredLeases(ctx, dbc)
+		if err != nil {
+			// ReturnNoErr: Log and try again next period.
+			log.Error(ctx, errors.Wrap(err, "delete expired leases"))
+		}
+
+		time.Sleep(period)
+	}
+}
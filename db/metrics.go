@@ -0,0 +1,23 @@
+package db
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	tombstonesReclaimedCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "goku",
+		Subsystem: "gc",
+		Name:      "tombstones_reclaimed_total",
+		Help:      "Total number of soft-deleted data rows hard-deleted",
+	})
+
+	leasesReclaimedCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "goku",
+		Subsystem: "gc",
+		Name:      "leases_reclaimed_total",
+		Help:      "Total number of expired lease rows without keys deleted",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(tombstonesReclaimedCounter, leasesReclaimedCounter)
+}
@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/log"
+	"github.com/luno/reflex/rsql"
+)
+
+var _ rsql.EventsNotifier = (*PollingNotifier)(nil)
+
+// PollingNotifier is an implementation of rsql EventsNotifier that supports multiple processes
+// (replicas) sharing the same DB. It polls the max event id and notifies its listeners
+// when it increases. It also notifies its listeners on writes in this process.
+type PollingNotifier struct {
+	memNotifier
+
+	dbc    *sql.DB
+	period time.Duration
+}
+
+// NewPollingNotifier returns a new PollingNotifier that polls the events table of the DB
+// every period until the context is canceled.
+func NewPollingNotifier(ctx context.Context, dbc *sql.DB, period time.Duration) *PollingNotifier {
+	n := &PollingNotifier{
+		dbc:    dbc,
+		period: period,
+	}
+
+	go n.pollForever(ctx)
+
+	return n
+}
+
+func (n *PollingNotifier) pollForever(ctx context.Context) {
+	t := time.NewTicker(n.period)
+	defer t.Stop()
+
+	var prev int64
+	local := notifier.C()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-local:
+			// Local write, listen again.
+			local = notifier.C()
+			n.Notify()
+		case <-t.C:
+			id, err := getMaxEventID(ctx, n.dbc)
+			if ctx.Err() != nil {
+				return
+			} else if err != nil {
+				// ReturnNoErr: Log and try again next period.
+				log.Error(ctx, errors.Wrap(err, "polling notifier"))
+				continue
+			}
+
+			if id > prev {
+				prev = id
+				n.Notify()
+			}
+		}
+	}
+}
+
+func getMaxEventID(ctx context.Context, dbc *sql.DB) (int64, error) {
+	var id sql.NullInt64
+	err := dbc.QueryRowContext(ctx, "select max(id) from events").Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id.Int64, nil
+}
@@ -0,0 +1,41 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/jtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollingNotifier(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dbc := ConnectForTesting(t)
+
+	n := NewPollingNotifier(ctx, dbc, time.Millisecond*10)
+
+	assertNotified := func(t *testing.T, ch <-chan struct{}) {
+		t.Helper()
+		select {
+		case <-ch:
+		case <-time.After(time.Second):
+			require.Fail(t, "notify timeout")
+		}
+	}
+
+	// Local write
+	ch := n.C()
+	err := Set(ctx, dbc, SetReq{Key: "key"})
+	jtest.RequireNil(t, err)
+	assertNotified(t, ch)
+
+	// Remote write (insert event without notifying the local notifier)
+	ch = n.C()
+	_, err = dbc.ExecContext(ctx, "insert into events set `key`=?, `type`=?, timestamp=now()",
+		"key", goku.EventTypeSet)
+	jtest.RequireNil(t, err)
+	assertNotified(t, ch)
+}
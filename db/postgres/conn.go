@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/luno/jettison/errors"
+)
+
+// sqlDriver is the database/sql driver of goku postgres connections. It wraps the
+// lib/pq driver and rebinds the placeholders and quoted identifiers of the db package
+// queries, see rebind. Statements that differ in more than syntax are provided by pgDriver.
+type sqlDriver struct{}
+
+func (sqlDriver) Open(dsn string) (driver.Conn, error) {
+	cn, err := pq.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapConn(cn)
+}
+
+func (sqlDriver) OpenConnector(dsn string) (driver.Connector, error) {
+	c, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return connector{pq: c}, nil
+}
+
+type connector struct {
+	pq *pq.Connector
+}
+
+func (c connector) Connect(ctx context.Context) (driver.Conn, error) {
+	cn, err := c.pq.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapConn(cn)
+}
+
+func (connector) Driver() driver.Driver {
+	return sqlDriver{}
+}
+
+// pqConn defines the interfaces implemented by lib/pq connections.
+type pqConn interface {
+	driver.Conn
+	driver.ConnPrepareContext
+	driver.ConnBeginTx
+	driver.QueryerContext
+	driver.ExecerContext
+	driver.Pinger
+	driver.SessionResetter
+	driver.Validator
+}
+
+func wrapConn(cn driver.Conn) (driver.Conn, error) {
+	pc, ok := cn.(pqConn)
+	if !ok {
+		cn.Close()
+		return nil, errors.New("unsupported lib/pq connection")
+	}
+
+	return &conn{pq: pc}, nil
+}
+
+type conn struct {
+	pq pqConn
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.pq.Prepare(rebind(query))
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return c.pq.PrepareContext(ctx, rebind(query))
+}
+
+func (c *conn) Close() error {
+	return c.pq.Close()
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return c.pq.Begin()
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.pq.BeginTx(ctx, opts)
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.pq.QueryContext(ctx, rebind(query), args)
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.pq.ExecContext(ctx, rebind(query), args)
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	return c.pq.Ping(ctx)
+}
+
+func (c *conn) ResetSession(ctx context.Context) error {
+	return c.pq.ResetSession(ctx)
+}
+
+func (c *conn) IsValid() bool {
+	return c.pq.IsValid()
+}
+
+// rebind returns the query with ? placeholders replaced by numbered postgres placeholders
+// and backticks replaced by double quotes. String literals are not modified.
+func rebind(query string) string {
+	var (
+		b       strings.Builder
+		n       int
+		literal bool
+	)
+	for _, r := range query {
+		switch {
+		case r == '\'':
+			literal = !literal
+		case literal:
+		case r == '?':
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		case r == '`':
+			r = '"'
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		In  string
+		Out string
+	}{
+		{
+			In:  "select 1",
+			Out: "select 1",
+		},
+		{
+			In:  "select value from data where `key`=? and version=?",
+			Out: `select value from data where "key"=$1 and version=$2`,
+		},
+		{
+			In:  "select id from events where `key` like ? escape '!' and id>?",
+			Out: `select id from events where "key" like $1 escape '!' and id>$2`,
+		},
+		{
+			In:  "insert into events (id, `key`) values (?, '?`')",
+			Out: `insert into events (id, "key") values ($1, '?` + "`" + `')`,
+		},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.Out, rebind(test.In))
+	}
+}
@@ -0,0 +1,141 @@
+// Package postgres provides the postgres storage backend of goku. It registers the
+// postgres db.Driver, so connections returned by Connect can be used with the db package
+// and db.NewStore in place of mysql connections.
+//
+// Create the schema in schema.sql before connecting.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/corverroos/goku/db"
+	"github.com/lib/pq"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/jtest"
+)
+
+func init() {
+	db.RegisterDriver(sqlDriver{}, pgDriver{})
+}
+
+// Connect returns a connection to the postgres database of the lib/pq data source name,
+// either a URL or key-value pairs.
+func Connect(dsn string) (*sql.DB, error) {
+	c, err := sqlDriver{}.OpenConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.OpenDB(c), nil
+}
+
+const envTestDSN = "GOKU_TEST_POSTGRES"
+
+// ConnectForTesting returns a connection to a newly created schema in the test database
+// with schema.sql applied. Test cleanup automatically drops the schema. The test database
+// URL is provided by the GOKU_TEST_POSTGRES environment variable, eg.
+// "postgres://postgres@localhost:5432/postgres?sslmode=disable". The test is skipped
+// if it is not set.
+func ConnectForTesting(t *testing.T) *sql.DB {
+	ctx := context.Background()
+
+	dsn, ok := os.LookupEnv(envTestDSN)
+	if !ok {
+		t.Skip("postgres tests require " + envTestDSN)
+	}
+
+	root, err := Connect(dsn)
+	jtest.RequireNil(t, err)
+
+	name := fmt.Sprintf("goku_%d", time.Now().UnixNano())
+	_, err = root.ExecContext(ctx, "create schema "+name)
+	jtest.RequireNil(t, err)
+
+	u, err := url.Parse(dsn)
+	jtest.RequireNil(t, err)
+	q := u.Query()
+	q.Set("search_path", name)
+	u.RawQuery = q.Encode()
+
+	dbc, err := Connect(u.String())
+	jtest.RequireNil(t, err)
+
+	for _, q := range getSchema(t) {
+		_, err := dbc.ExecContext(ctx, q)
+		jtest.RequireNil(t, err)
+	}
+
+	t.Cleanup(func() {
+		jtest.RequireNil(t, dbc.Close())
+
+		_, err := root.ExecContext(ctx, "drop schema "+name+" cascade")
+		jtest.RequireNil(t, err)
+		jtest.RequireNil(t, root.Close())
+	})
+
+	return dbc
+}
+
+func getSchema(t *testing.T) []string {
+	_, f, _, _ := runtime.Caller(0)
+	file := strings.ReplaceAll(f, "postgres.go", "schema.sql")
+	b, err := ioutil.ReadFile(file)
+	jtest.RequireNil(t, err)
+
+	ql := string(b)
+	ql = strings.TrimSpace(ql)
+	ql = strings.Trim(ql, ";")
+	return strings.Split(ql, ";")
+}
+
+type pgDriver struct{}
+
+// ForUpdate returns the row locking clause.
+func (pgDriver) ForUpdate() string {
+	return " for update"
+}
+
+const errUniqueViolation = "23505"
+
+// IsDuplicateKeyErr returns true if the provided error is a postgres unique_violation error.
+func (pgDriver) IsDuplicateKeyErr(err error) bool {
+	pe := new(pq.Error)
+	if !errors.As(err, &pe) {
+		return false
+	}
+
+	return pe.Code == errUniqueViolation
+}
+
+// ReturningID returns the returning clause since postgres doesn't support LastInsertId.
+func (pgDriver) ReturningID() string {
+	return " returning id"
+}
+
+// IncrementSeq returns the sequence upsert statement.
+func (pgDriver) IncrementSeq() string {
+	return "insert into sequences (prefix, seq) values (?, 1) " +
+		"on conflict (prefix) do update set seq=sequences.seq+1"
+}
+
+// CompactEvents returns the compaction statement with a subquery instead of a multi-table update.
+func (pgDriver) CompactEvents() string {
+	return "update events set metadata=null where id in (select e.id from events e " +
+		"join events n on n.`key`=e.`key` and n.id>e.id and n.`type`!=0 " +
+		"where n.id>=? and n.id<=? and e.metadata is not null)"
+}
+
+// DeleteLimit returns the delete statement with the limit in a subquery since delete doesn't support limits.
+func (pgDriver) DeleteLimit(table, pk, where string) string {
+	return "delete from " + table + " where " + pk + " in " +
+		"(select " + pk + " from " + table + " where " + where + " limit ?)"
+}
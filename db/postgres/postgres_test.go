@@ -0,0 +1,20 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/corverroos/goku"
+	"github.com/corverroos/goku/client/logical"
+	"github.com/corverroos/goku/clienttest"
+	"github.com/corverroos/goku/db"
+	"github.com/corverroos/goku/db/postgres"
+)
+
+func TestSuite(t *testing.T) {
+	clienttest.RunSuite(t, func(t *testing.T) goku.Client {
+		db.CleanCache(t)
+		dbc := postgres.ConnectForTesting(t)
+		dbc.SetMaxOpenConns(100)
+		return logical.New(db.NewStore(dbc, dbc))
+	})
+}
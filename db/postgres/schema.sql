@@ -0,0 +1,61 @@
+-- data stores the mutable key-values
+-- Keys use the "C" collation to order and match keys by bytes.
+create table data (
+ key varchar(255) collate "C" not null,
+ value bytea,
+ version bigint not null,
+ created_ref bigint not null,
+ updated_ref bigint not null,
+ deleted_ref bigint,
+ lease_id bigint,
+
+ primary key (key)
+);
+
+create index data_lease_id on data (lease_id);
+create index data_deleted_ref on data (deleted_ref);
+
+-- events stores the immutable append-only key-value update notification events.
+create table events (
+ id bigserial not null,
+ type int not null,
+ key varchar(255) collate "C" not null,
+ timestamp timestamptz not null,
+ metadata bytea,
+ version bigint,
+ lease_id bigint,
+
+ primary key (id)
+);
+
+create index events_key_id on events (key, id);
+
+-- leases store the mutable key-value leases.
+create table leases (
+ id bigserial not null,
+ version bigint not null,
+ expires_at timestamptz,
+ expired boolean not null default false,
+
+ primary key (id)
+);
+
+create index leases_expires_at on leases (expires_at);
+
+-- sequences stores the last allocated sequence number per prefix of sequential keys.
+create table sequences (
+ prefix varchar(255) collate "C" not null,
+ seq bigint not null,
+
+ primary key (prefix)
+);
+
+-- compactions stores the event log compaction points.
+create table compactions (
+ id bigserial not null,
+ ref bigint not null,
+ created_at timestamptz not null,
+ completed boolean not null default false,
+
+ primary key (id)
+);
@@ -9,7 +9,8 @@ create table data (
  lease_id bigint,
 
  primary key (`key`),
- index lease_id (lease_id)
+ index lease_id (lease_id),
+ index deleted_ref (deleted_ref)
 );
 
 -- events stores the immutable append-only key-value update notification events.
@@ -19,8 +20,11 @@ create table events (
  `key` varchar(255) not null,
  timestamp datetime(3) not null,
  metadata mediumblob,
+ version bigint,
+ lease_id bigint,
 
- primary key (id)
+ primary key (id),
+ index key_id (`key`, id)
 );
 
 -- leases store the mutable key-value leases.
@@ -33,3 +37,21 @@ create table leases (
  primary key (id),
  index expires_at (expires_at)
 );
+
+-- sequences stores the last allocated sequence number per prefix of sequential keys.
+create table sequences (
+ prefix varchar(255) not null,
+ seq bigint not null,
+
+ primary key (prefix)
+);
+
+-- compactions stores the event log compaction points.
+create table compactions (
+ id bigint not null auto_increment,
+ ref bigint not null,
+ created_at datetime(3) not null,
+ completed bool not null default false,
+
+ primary key (id)
+);
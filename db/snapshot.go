@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/corverroos/goku"
+)
+
+// ListSnapshot returns a consistent snapshot of the key-values with keys matching the prefix
+// together with the event refs it reflects. The key-values and event ids are read in a
+// single read-only repeatable read transaction.
+//
+// Events of transactions that were in flight when the snapshot was taken are not reflected
+// even if their ids are lower than the max event id, so the ids missing after the committed
+// events cursor are returned as pending. All events up to and including the cursor must be committed.
+func ListSnapshot(ctx context.Context, dbc *sql.DB, prefix string, cursor int64) (goku.Snapshot, error) {
+	tx, err := dbc.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return goku.Snapshot{}, err
+	}
+	defer tx.Rollback()
+
+	// Note the first read establishes the snapshot.
+	var snap goku.Snapshot
+	snap.Ref, snap.Pending, err = getPendingEventIDs(ctx, tx, cursor)
+	if err != nil {
+		return goku.Snapshot{}, err
+	}
+
+	snap.KVs, err = listWhere(ctx, tx, "`key` like ? escape '!' and deleted_ref is null order by `key`",
+		likePrefix(prefix))
+	if err != nil {
+		return goku.Snapshot{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return goku.Snapshot{}, err
+	}
+
+	return snap, nil
+}
+
+// ListSnapshot returns a consistent snapshot of the key-values with keys matching the prefix,
+// see ListSnapshot. Snapshots only check events after the committed events cursor for pending
+// ids and advance the cursor to the first pending id.
+func (s *PrefixStreamer) ListSnapshot(ctx context.Context, prefix string) (goku.Snapshot, error) {
+	cursor, err := s.getSnapshotCursor(ctx)
+	if err != nil {
+		return goku.Snapshot{}, err
+	}
+
+	snap, err := ListSnapshot(ctx, s.rdbc, prefix, cursor)
+	if err != nil {
+		return goku.Snapshot{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// All events up to the first pending id are committed.
+	after := snap.After()
+	if s.from < 0 || after >= s.from {
+		s.from = 0
+		if after > s.committed {
+			s.committed = after
+		}
+	}
+
+	return snap, nil
+}
+
+// getSnapshotCursor returns the committed events cursor if it is valid from the first event,
+// otherwise the max id at or below the current committed position with all prior events committed.
+func (s *PrefixStreamer) getSnapshotCursor(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	from, committed := s.from, s.committed
+	s.mu.Unlock()
+
+	if from == 0 {
+		return committed, nil
+	} else if from < 0 {
+		var err error
+		committed, err = getMaxEventID(ctx, s.rdbc)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return getMaxConsecutiveEventID(ctx, s.rdbc, committed)
+}
+
+// getMaxConsecutiveEventID returns the max event id at or below the upper bound with all
+// lower event ids existing.
+func getMaxConsecutiveEventID(ctx context.Context, dbc *sql.DB, upper int64) (int64, error) {
+	lo, hi := int64(0), upper
+	for lo < hi {
+		mid := lo + (hi-lo+1)/2
+
+		var n int64
+		err := dbc.QueryRowContext(ctx, "select count(*) from events where id<=?", mid).Scan(&n)
+		if err != nil {
+			return 0, err
+		}
+
+		if n == mid {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+
+	return lo, nil
+}
+
+// getPendingEventIDs returns the max event id and the missing event ids (ascending) after the cursor.
+func getPendingEventIDs(ctx context.Context, dbc dbc, cursor int64) (int64, []int64, error) {
+	rows, err := dbc.QueryContext(ctx, "select id from events where id>? order by id", cursor)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer rows.Close()
+
+	var (
+		max     = cursor
+		pending []int64
+	)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return 0, nil, err
+		}
+
+		for missing := max + 1; missing < id; missing++ {
+			pending = append(pending, missing)
+		}
+		max = id
+	}
+
+	return max, pending, rows.Err()
+}
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/jtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListSnapshotPending(t *testing.T) {
+	ctx := context.Background()
+	dbc := ConnectForTesting(t)
+	dbc.SetMaxOpenConns(10) // Allow an in-flight transaction.
+
+	err := Set(ctx, dbc, SetReq{Key: "key1"}) // Event 1
+	jtest.RequireNil(t, err)
+
+	// Start an in-flight transaction.
+	tx, err := dbc.Begin()
+	jtest.RequireNil(t, err)
+	defer tx.Rollback()
+
+	err = setTx(ctx, tx, getDriver(dbc), SetReq{Key: "key2"}) // Event 2
+	jtest.RequireNil(t, err)
+
+	// Events 3 to 1002, more than a page of events after the pending event.
+	for i := 0; i < 1000; i++ {
+		err = Set(ctx, dbc, SetReq{Key: "key3"})
+		jtest.RequireNil(t, err)
+	}
+
+	s := NewPrefixStreamer(dbc, dbc, nil)
+
+	snap, err := s.ListSnapshot(ctx, "")
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(1002), snap.Ref)
+	require.Equal(t, []int64{2}, snap.Pending)
+	require.Equal(t, int64(1), snap.After())
+	require.False(t, snap.Reflects(2))
+	require.True(t, snap.Reflects(1002))
+	require.Len(t, snap.KVs, 2)
+
+	jtest.RequireNil(t, tx.Commit())
+
+	snap, err = s.ListSnapshot(ctx, "key")
+	jtest.RequireNil(t, err)
+	require.Equal(t, goku.Snapshot{Ref: 1002, KVs: snap.KVs}, snap)
+	require.Len(t, snap.KVs, 3)
+}
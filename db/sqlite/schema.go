@@ -0,0 +1,47 @@
+package sqlite
+
+// schema is the sqlite equivalent of db/schema.sql.
+var schema = []string{
+	// data stores the mutable key-values
+	"create table if not exists data (" +
+		"`key` varchar(255) not null primary key, " +
+		"value blob, " +
+		"version bigint not null, " +
+		"created_ref bigint not null, " +
+		"updated_ref bigint not null, " +
+		"deleted_ref bigint, " +
+		"lease_id bigint)",
+	"create index if not exists data_lease_id on data (lease_id)",
+	"create index if not exists data_deleted_ref on data (deleted_ref)",
+
+	// events stores the immutable append-only key-value update notification events.
+	"create table if not exists events (" +
+		"id integer primary key autoincrement, " +
+		"type int not null, " +
+		"`key` varchar(255) not null, " +
+		"timestamp datetime not null, " +
+		"metadata blob, " +
+		"version bigint, " +
+		"lease_id bigint)",
+	"create index if not exists events_key_id on events (`key`, id)",
+
+	// leases store the mutable key-value leases.
+	"create table if not exists leases (" +
+		"id integer primary key autoincrement, " +
+		"version bigint not null, " +
+		"expires_at datetime, " +
+		"expired boolean not null default false)",
+	"create index if not exists leases_expires_at on leases (expires_at)",
+
+	// sequences stores the last allocated sequence number per prefix of sequential keys.
+	"create table if not exists sequences (" +
+		"prefix varchar(255) not null primary key, " +
+		"seq bigint not null)",
+
+	// compactions stores the event log compaction points.
+	"create table if not exists compactions (" +
+		"id integer primary key autoincrement, " +
+		"ref bigint not null, " +
+		"created_at datetime not null, " +
+		"completed boolean not null default false)",
+}
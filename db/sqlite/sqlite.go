@@ -0,0 +1,104 @@
+// Package sqlite provides the embedded sqlite storage backend of goku. It registers the
+// sqlite db.Driver, so connections returned by Connect can be used with the db package and
+// db.NewStore in place of mysql connections.
+//
+// Note that it requires cgo.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/corverroos/goku/db"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/jtest"
+	"github.com/mattn/go-sqlite3"
+)
+
+func init() {
+	db.RegisterDriver(&sqlite3.SQLiteDriver{}, driver{})
+}
+
+// params are the connection parameters:
+//   - Write transactions lock the database on begin, instead of failing to upgrade read locks.
+//   - Connections wait for locks held by other processes.
+//   - LIKE is case sensitive like key equality and ordering (which are binary).
+//   - Timestamps are returned in UTC.
+const params = "?_txlock=immediate&_busy_timeout=5000&_cslike=true&_journal_mode=WAL&_loc=UTC"
+
+// Connect opens the sqlite database file at the path and creates the goku schema if it doesn't exist.
+//
+// The connection pool is limited to a single connection since sqlite serialises writes.
+func Connect(path string) (*sql.DB, error) {
+	dbc, err := sql.Open("sqlite3", "file:"+path+params)
+	if err != nil {
+		return nil, err
+	}
+
+	dbc.SetMaxOpenConns(1)
+
+	for _, q := range schema {
+		_, err := dbc.ExecContext(context.Background(), q)
+		if err != nil {
+			return nil, errors.Wrap(err, "create schema")
+		}
+	}
+
+	return dbc, nil
+}
+
+// ConnectForTesting returns a connection to a new sqlite database in a temporary directory.
+func ConnectForTesting(t *testing.T) *sql.DB {
+	dbc, err := Connect(filepath.Join(t.TempDir(), "goku.db"))
+	jtest.RequireNil(t, err)
+
+	t.Cleanup(func() {
+		jtest.RequireNil(t, dbc.Close())
+	})
+
+	return dbc
+}
+
+type driver struct{}
+
+// ForUpdate returns an empty clause since sqlite serialises write transactions.
+func (driver) ForUpdate() string {
+	return ""
+}
+
+// IsDuplicateKeyErr returns true if the provided error is a sqlite primary key or unique constraint error.
+func (driver) IsDuplicateKeyErr(err error) bool {
+	var se sqlite3.Error
+	if !errors.As(err, &se) {
+		return false
+	}
+
+	return se.ExtendedCode == sqlite3.ErrConstraintPrimaryKey ||
+		se.ExtendedCode == sqlite3.ErrConstraintUnique
+}
+
+// ReturningID returns an empty clause since sqlite provides the id of inserted rows.
+func (driver) ReturningID() string {
+	return ""
+}
+
+// IncrementSeq returns the sequence upsert statement.
+func (driver) IncrementSeq() string {
+	return "insert into sequences (prefix, seq) values (?, 1) " +
+		"on conflict (prefix) do update set seq=sequences.seq+1"
+}
+
+// CompactEvents returns the compaction statement with a subquery instead of a multi-table update.
+func (driver) CompactEvents() string {
+	return "update events set metadata=null where id in (select e.id from events e " +
+		"join events n on n.`key`=e.`key` and n.id>e.id and n.`type`!=0 " +
+		"where n.id>=? and n.id<=? and e.metadata is not null)"
+}
+
+// DeleteLimit returns the delete statement with the limit in a subquery since delete doesn't support limits.
+func (driver) DeleteLimit(table, pk, where string) string {
+	return "delete from " + table + " where " + pk + " in " +
+		"(select " + pk + " from " + table + " where " + where + " limit ?)"
+}
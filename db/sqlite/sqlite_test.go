@@ -0,0 +1,265 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/corverroos/goku/client/logical"
+	"github.com/corverroos/goku/clienttest"
+	"github.com/corverroos/goku/db"
+	"github.com/corverroos/goku/db/sqlite"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/jtest"
+	"github.com/luno/reflex"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuite(t *testing.T) {
+	clienttest.RunSuite(t, func(t *testing.T) goku.Client {
+		db.CleanCache(t)
+		dbc := sqlite.ConnectForTesting(t)
+		return logical.New(db.NewStore(dbc, dbc))
+	})
+}
+
+func TestStreamLag(t *testing.T) {
+	ctx := context.Background()
+	db.CleanCache(t)
+	dbc := sqlite.ConnectForTesting(t)
+	cl := logical.New(db.NewStore(dbc, dbc))
+
+	err := cl.Set(ctx, "key", nil)
+	jtest.RequireNil(t, err)
+
+	sc, err := cl.Stream("")(ctx, "", reflex.WithStreamLag(time.Hour), reflex.WithStreamToHead())
+	jtest.RequireNil(t, err)
+
+	_, err = sc.Recv()
+	jtest.Require(t, reflex.ErrHeadReached, err)
+
+	sc, err = cl.Stream("key")(ctx, "", reflex.WithStreamLag(time.Millisecond), reflex.WithStreamToHead())
+	jtest.RequireNil(t, err)
+
+	time.Sleep(time.Millisecond * 10)
+
+	e, err := sc.Recv()
+	jtest.RequireNil(t, err)
+	require.Equal(t, "key", e.ForeignID)
+}
+
+// failingStore injects update races into sets of the embedded store.
+type failingStore struct {
+	goku.Store
+}
+
+func (s failingStore) Set(context.Context, string, []byte, goku.SetOptions) error {
+	return errors.Wrap(goku.ErrUpdateRace, "")
+}
+
+func TestStoreDecorator(t *testing.T) {
+	ctx := context.Background()
+	db.CleanCache(t)
+	dbc := sqlite.ConnectForTesting(t)
+	store := db.NewStore(dbc, dbc)
+
+	err := logical.New(store).Set(ctx, "key", []byte("value"))
+	jtest.RequireNil(t, err)
+
+	cl := logical.New(failingStore{Store: store})
+
+	err = cl.Set(ctx, "key", nil)
+	jtest.Require(t, goku.ErrUpdateRace, err)
+
+	kv, err := cl.Get(ctx, "key")
+	jtest.RequireNil(t, err)
+	require.Equal(t, []byte("value"), kv.Value)
+}
+
+func TestCompact(t *testing.T) {
+	ctx := context.Background()
+	db.CleanCache(t)
+	dbc := sqlite.ConnectForTesting(t)
+
+	for i, key := range []string{"key1", "key2", "key1", "key2", "key1"} {
+		err := db.Set(ctx, dbc, db.SetReq{Key: key, Value: []byte{byte('0' + i)}})
+		jtest.RequireNil(t, err)
+	}
+
+	err := db.Compact(ctx, dbc, db.CompactReq{Ref: 3})
+	jtest.RequireNil(t, err)
+
+	ref, err := db.GetCompactedRef(ctx, dbc)
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(3), ref)
+
+	// Only superseded ref 1 is compacted.
+	var values []string
+	err = db.History(ctx, dbc, "key1", goku.HistoryOptions{}, func(r goku.Revision) error {
+		values = append(values, string(r.Value))
+		return nil
+	})
+	jtest.RequireNil(t, err)
+	require.Equal(t, []string{"", "2", "4"}, values)
+
+	kv, err := db.GetAt(ctx, dbc, "key2", 3)
+	jtest.RequireNil(t, err)
+	require.Equal(t, "1", string(kv.Value))
+
+	_, err = db.GetAt(ctx, dbc, "key1", 2)
+	jtest.Require(t, goku.ErrCompacted, err)
+
+	// Incomplete compactions are resumed by the next compaction.
+	_, err = dbc.Exec("insert into compactions (ref, created_at) values (5, ?)", time.Now())
+	jtest.RequireNil(t, err)
+
+	err = db.Compact(ctx, dbc, db.CompactReq{Ref: 5})
+	jtest.RequireNil(t, err)
+
+	values = nil
+	err = db.History(ctx, dbc, "key2", goku.HistoryOptions{}, func(r goku.Revision) error {
+		values = append(values, string(r.Value))
+		return nil
+	})
+	jtest.RequireNil(t, err)
+	require.Equal(t, []string{"", "3"}, values)
+
+	// Streams from the start would skip compacted events.
+	_, err = db.ToStream(dbc)(ctx, "")
+	jtest.Require(t, goku.ErrCompacted, err)
+
+	cl := logical.New(db.NewStore(dbc, dbc))
+	_, err = cl.Stream("key")(ctx, "")
+	jtest.Require(t, goku.ErrCompacted, err)
+
+	sc, err := db.ToStream(dbc)(ctx, "5", reflex.WithStreamToHead())
+	jtest.RequireNil(t, err)
+	_, err = sc.Recv()
+	jtest.Require(t, reflex.ErrHeadReached, err)
+}
+
+func TestGC(t *testing.T) {
+	ctx := context.Background()
+	db.CleanCache(t)
+	dbc := sqlite.ConnectForTesting(t)
+
+	for _, key := range []string{"key1", "key2", "key3"} {
+		err := db.Set(ctx, dbc, db.SetReq{Key: key})
+		jtest.RequireNil(t, err)
+	}
+
+	_, err := db.Delete(ctx, dbc, db.DeleteReq{Key: "key1"})
+	jtest.RequireNil(t, err)
+
+	kv, err := db.Get(ctx, dbc, "key2")
+	jtest.RequireNil(t, err)
+	err = db.ExpireLease(ctx, dbc, kv.LeaseID)
+	jtest.RequireNil(t, err)
+
+	n, err := db.DeleteTombstones(ctx, dbc, time.Now().Add(-time.Hour))
+	jtest.RequireNil(t, err)
+	require.Zero(t, n)
+
+	n, err = db.DeleteTombstones(ctx, dbc, time.Now().Add(time.Hour))
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(2), n)
+
+	n, err = db.DeleteExpiredLeases(ctx, dbc)
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(1), n)
+
+	var count int
+	err = dbc.QueryRow("select count(*) from data").Scan(&count)
+	jtest.RequireNil(t, err)
+	require.Equal(t, 1, count)
+
+	err = db.ExpireLease(ctx, dbc, kv.LeaseID)
+	jtest.Require(t, goku.ErrLeaseNotFound, err)
+
+	// Point-in-time versions of recreated keys match the restarted versions.
+	err = db.Set(ctx, dbc, db.SetReq{Key: "key1"})
+	jtest.RequireNil(t, err)
+
+	kv, err = db.Get(ctx, dbc, "key1")
+	jtest.RequireNil(t, err)
+	require.Equal(t, int64(1), kv.Version)
+
+	at, err := db.GetAt(ctx, dbc, "key1", kv.UpdatedRef)
+	jtest.RequireNil(t, err)
+	require.Equal(t, kv.Version, at.Version)
+}
+
+func TestPrefixStream(t *testing.T) {
+	ctx := context.Background()
+	db.CleanCache(t)
+	dbc := sqlite.ConnectForTesting(t)
+	cl := logical.New(db.NewStore(dbc, dbc))
+
+	// More than a batch of events between the matching events.
+	err := cl.Set(ctx, "a/1", nil)
+	jtest.RequireNil(t, err)
+
+	for i := 0; i < 1500; i++ {
+		err := cl.Set(ctx, "b/1", nil)
+		jtest.RequireNil(t, err)
+	}
+
+	err = cl.Set(ctx, "a/2", nil)
+	jtest.RequireNil(t, err)
+
+	sc, err := cl.Stream("a/")(ctx, "", reflex.WithStreamToHead())
+	jtest.RequireNil(t, err)
+
+	for _, key := range []string{"a/1", "a/2"} {
+		e, err := sc.Recv()
+		jtest.RequireNil(t, err)
+		require.Equal(t, key, e.ForeignID)
+	}
+
+	_, err = sc.Recv()
+	jtest.Require(t, reflex.ErrHeadReached, err)
+
+	// Streams are triggered by new events.
+	sc, err = cl.Stream("a/")(ctx, "", reflex.WithStreamFromHead())
+	jtest.RequireNil(t, err)
+
+	err = cl.Set(ctx, "a/3", nil)
+	jtest.RequireNil(t, err)
+
+	e, err := sc.Recv()
+	jtest.RequireNil(t, err)
+	require.Equal(t, "a/3", e.ForeignID)
+}
+
+func TestPrefixStreamBeforeCursor(t *testing.T) {
+	ctx := context.Background()
+	db.CleanCache(t)
+	dbc := sqlite.ConnectForTesting(t)
+	cl := logical.New(db.NewStore(dbc, dbc))
+
+	for _, key := range []string{"a/1", "b/1", "a/2"} {
+		err := cl.Set(ctx, key, nil)
+		jtest.RequireNil(t, err)
+	}
+
+	// Initialise the committed cursor at the head.
+	sc, err := cl.Stream("a/")(ctx, "", reflex.WithStreamFromHead(), reflex.WithStreamToHead())
+	jtest.RequireNil(t, err)
+
+	_, err = sc.Recv()
+	jtest.Require(t, reflex.ErrHeadReached, err)
+
+	// Streams from before the cursor still return all events.
+	sc, err = cl.Stream("a/")(ctx, "", reflex.WithStreamToHead())
+	jtest.RequireNil(t, err)
+
+	for _, key := range []string{"a/1", "a/2"} {
+		e, err := sc.Recv()
+		jtest.RequireNil(t, err)
+		require.Equal(t, key, e.ForeignID)
+	}
+
+	_, err = sc.Recv()
+	jtest.Require(t, reflex.ErrHeadReached, err)
+}
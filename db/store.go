@@ -0,0 +1,202 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/reflex"
+	"github.com/luno/reflex/rsql"
+)
+
+var _ goku.Store = (*Store)(nil)
+
+// StoreOption configures a Store.
+type StoreOption func(*Store)
+
+// WithNotifier returns an option to trigger streams with the provided notifier instead of
+// the default process-local notifier. Use NewPollingNotifier when running multiple replicas.
+func WithNotifier(n rsql.EventsNotifier) StoreOption {
+	return func(s *Store) {
+		s.notifier = n
+	}
+}
+
+// NewStore returns a goku store of the write and read connections. A nil read
+// connection defaults to the write connection.
+func NewStore(wdbc, rdbc *sql.DB, opts ...StoreOption) *Store {
+	if rdbc == nil {
+		rdbc = wdbc
+	}
+
+	s := &Store{
+		wdbc: wdbc,
+		rdbc: rdbc,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.streamer = NewPrefixStreamer(wdbc, rdbc, s.notifier)
+
+	return s
+}
+
+// Store implements goku.Store with the functions of this package. Writes use
+// the write connection and reads use the read connection.
+type Store struct {
+	wdbc, rdbc *sql.DB
+	notifier   rsql.EventsNotifier
+	streamer   *PrefixStreamer
+}
+
+func (s *Store) Set(ctx context.Context, key string, value []byte, opts goku.SetOptions) error {
+	if opts.Ephemeral {
+		return goku.ErrEphemeralUnsupported
+	}
+
+	return Set(ctx, s.wdbc, SetReq{
+		Key:         key,
+		Value:       value,
+		ExpiresAt:   opts.ExpiresAt,
+		LeaseID:     opts.LeaseID,
+		PrevVersion: opts.PrevVersion,
+		CreateOnly:  opts.CreateOnly,
+	})
+}
+
+func (s *Store) SetMany(ctx context.Context, items []goku.SetItem) error {
+	var reqs []SetReq
+	for _, item := range items {
+		if item.SetOptions.Ephemeral {
+			return goku.ErrEphemeralUnsupported
+		}
+
+		reqs = append(reqs, SetReq{
+			Key:         item.Key,
+			Value:       item.Value,
+			ExpiresAt:   item.SetOptions.ExpiresAt,
+			LeaseID:     item.SetOptions.LeaseID,
+			PrevVersion: item.SetOptions.PrevVersion,
+			CreateOnly:  item.SetOptions.CreateOnly,
+		})
+	}
+
+	return SetMany(ctx, s.wdbc, reqs)
+}
+
+func (s *Store) Create(ctx context.Context, prefix string, value []byte, opts goku.SetOptions) (string, error) {
+	if opts.Ephemeral {
+		return "", goku.ErrEphemeralUnsupported
+	}
+
+	return Create(ctx, s.wdbc, CreateReq{
+		Prefix:    prefix,
+		Value:     value,
+		LeaseID:   opts.LeaseID,
+		ExpiresAt: opts.ExpiresAt,
+	})
+}
+
+func (s *Store) Increment(ctx context.Context, key string, delta int64) (int64, error) {
+	return Increment(ctx, s.wdbc, key, delta)
+}
+
+func (s *Store) Delete(ctx context.Context, key string, opts goku.DeleteOptions) error {
+	prev, err := Delete(ctx, s.wdbc, DeleteReq{
+		Key:         key,
+		PrevVersion: opts.PrevVersion,
+		LeaseID:     opts.LeaseID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.Prev != nil {
+		*opts.Prev = prev
+	}
+
+	return nil
+}
+
+func (s *Store) DeletePrefix(ctx context.Context, prefix string, opts goku.DeletePrefixOptions) (int64, error) {
+	return DeletePrefix(ctx, s.wdbc, prefix, opts)
+}
+
+func (s *Store) Get(ctx context.Context, key string) (goku.KV, error) {
+	return Get(ctx, s.rdbc, key)
+}
+
+func (s *Store) GetMany(ctx context.Context, keys []string) ([]goku.KV, error) {
+	return GetMany(ctx, s.rdbc, keys)
+}
+
+func (s *Store) List(ctx context.Context, prefix string, opts goku.ListOptions, fn func(goku.KV) error) error {
+	return List(ctx, s.rdbc, prefix, opts, fn)
+}
+
+func (s *Store) ListSnapshot(ctx context.Context, prefix string) (goku.Snapshot, error) {
+	return s.streamer.ListSnapshot(ctx, prefix)
+}
+
+func (s *Store) GetAt(ctx context.Context, key string, ref int64) (goku.KV, error) {
+	return GetAt(ctx, s.rdbc, key, ref)
+}
+
+func (s *Store) ListAt(ctx context.Context, prefix string, ref int64, fn func(goku.KV) error) error {
+	return ListAt(ctx, s.rdbc, prefix, ref, fn)
+}
+
+func (s *Store) History(ctx context.Context, key string, opts goku.HistoryOptions, fn func(goku.Revision) error) error {
+	return History(ctx, s.rdbc, key, opts, fn)
+}
+
+func (s *Store) Txn(ctx context.Context, compares []goku.Compare, then []goku.Op, els []goku.Op) (goku.TxnResponse, error) {
+	for _, ops := range [][]goku.Op{then, els} {
+		for _, op := range ops {
+			if op.SetOptions.Ephemeral {
+				return goku.TxnResponse{}, goku.ErrEphemeralUnsupported
+			}
+		}
+	}
+
+	return Txn(ctx, s.wdbc, TxnReq{
+		Compares: compares,
+		Then:     then,
+		Else:     els,
+	})
+}
+
+func (s *Store) GrantLease(ctx context.Context, expiresAt time.Time) (int64, error) {
+	return GrantLease(ctx, s.wdbc, expiresAt)
+}
+
+func (s *Store) UpdateLease(ctx context.Context, leaseID int64, expiresAt time.Time) error {
+	return UpdateLease(ctx, s.wdbc, leaseID, expiresAt)
+}
+
+func (s *Store) ExpireLease(ctx context.Context, leaseID int64) error {
+	return ExpireLease(ctx, s.wdbc, leaseID)
+}
+
+func (s *Store) GetLease(ctx context.Context, leaseID int64) (goku.Lease, error) {
+	return GetLease(ctx, s.rdbc, leaseID)
+}
+
+func (s *Store) ListLeaseKeys(ctx context.Context, leaseID int64, fn func(goku.KV) error) error {
+	return ListLeaseKeys(ctx, s.rdbc, leaseID, fn)
+}
+
+func (s *Store) ListLeases(ctx context.Context, opts goku.ListLeasesOptions) ([]goku.Lease, error) {
+	return ListLeases(ctx, s.rdbc, opts)
+}
+
+func (s *Store) Stream(prefix string) reflex.StreamFunc {
+	return s.streamer.Stream(prefix)
+}
+
+func (s *Store) WatchStream(prefix string) goku.WatchStreamFunc {
+	return s.streamer.WatchStream(prefix)
+}
@@ -0,0 +1,447 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/log"
+	"github.com/luno/reflex"
+	"github.com/luno/reflex/rsql"
+)
+
+// PrefixStreamer provides reflex streams of events with keys matching a prefix. The prefix
+// is filtered in the events query, so streams of narrow prefixes only load their own events.
+//
+// Rsql streams require consecutive event ids to detect uncommitted or rolled back events, so
+// prefix streams instead only query events up to the committed events cursor shared by all
+// streams of the streamer; all events up to the cursor are committed. The cursor is blocked
+// by gaps until they are filled with noop events.
+type PrefixStreamer struct {
+	wdbc, rdbc *sql.DB
+	notifier   rsql.EventsNotifier
+	table      *rsql.EventsTable
+
+	mu        sync.Mutex
+	from      int64 // from is the id after which the committed cursor is valid, -1 if not initialised.
+	committed int64
+	filling   bool
+}
+
+// NewPrefixStreamer returns a new PrefixStreamer using the provided notifier to trigger stream
+// clients. A nil notifier uses the default process-local notifier.
+func NewPrefixStreamer(wdbc, rdbc *sql.DB, n rsql.EventsNotifier) *PrefixStreamer {
+	s := &PrefixStreamer{
+		wdbc:     wdbc,
+		rdbc:     rdbc,
+		notifier: n,
+		from:     -1,
+	}
+
+	if n == nil {
+		// Use the default cached events table for the empty prefix.
+		s.notifier = notifier
+		s.table = events
+	} else {
+		s.table = NewEventsTable(n)
+		fillGaps(wdbc, s.table)
+	}
+
+	return s
+}
+
+// Stream returns a reflex stream function of events with keys matching the prefix.
+func (s *PrefixStreamer) Stream(prefix string) reflex.StreamFunc {
+	if prefix == "" {
+		return TableToStream(s.table, s.rdbc)
+	}
+
+	return func(ctx context.Context, after string, opts ...reflex.StreamOption) (reflex.StreamClient, error) {
+		return s.newStream(ctx, prefix, after, opts)
+	}
+}
+
+// WatchStream returns a stream function of typed events with keys matching the prefix.
+func (s *PrefixStreamer) WatchStream(prefix string) goku.WatchStreamFunc {
+	return func(ctx context.Context, after string, opts ...reflex.StreamOption) (goku.WatchStreamClient, error) {
+		c, err := s.newStream(ctx, prefix, after, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return &watchStream{c: c}, nil
+	}
+}
+
+// newStream returns a new prefix stream after the cursor with the compaction behaviour of TableToStream.
+func (s *PrefixStreamer) newStream(ctx context.Context, prefix string, after string,
+	opts []reflex.StreamOption) (*prefixStream, error) {
+
+	var o reflex.StreamOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	err := checkStreamCompacted(ctx, s.rdbc, after, o)
+	if err != nil {
+		return nil, err
+	}
+
+	var prev int64
+	if o.StreamFromHead {
+		prev, err = getMaxEventID(ctx, s.rdbc)
+	} else if after != "" {
+		prev, err = strconv.ParseInt(after, 10, 64)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &prefixStream{
+		s:      s,
+		ctx:    ctx,
+		prefix: prefix,
+		prev:   prev,
+		opts:   o,
+	}, nil
+}
+
+// getCommitted returns the committed events cursor for a stream after prev; all events after prev
+// up to the cursor are committed. It starts filling the first gap after the cursor (if any).
+//
+// The queries are done without holding the mutex, results are merged into the shared cursor
+// which never moves backwards.
+func (s *PrefixStreamer) getCommitted(ctx context.Context, prev int64) (int64, error) {
+	s.mu.Lock()
+	if s.from < 0 {
+		s.from = prev
+		s.committed = prev
+	}
+	from, committed := s.from, s.committed
+	s.mu.Unlock()
+
+	if prev < from {
+		// Events between prev and the valid range have not been checked yet.
+		gap, ok, err := getFirstGap(ctx, s.rdbc, prev, from)
+		if err != nil {
+			return 0, err
+		} else if !ok {
+			s.mu.Lock()
+			s.maybeFill(gap)
+			s.mu.Unlock()
+
+			return gap.Prev, nil
+		}
+
+		s.mu.Lock()
+		if prev < s.from {
+			s.from = prev
+		}
+		s.mu.Unlock()
+	}
+
+	for {
+		ids, err := getNextEventIDs(ctx, s.rdbc, committed, 0)
+		if err != nil {
+			return 0, err
+		}
+
+		next := committed
+		var gap *rsql.Gap
+		for _, id := range ids {
+			if id != next+1 {
+				gap = &rsql.Gap{Prev: next, Next: id}
+				break
+			}
+			next = id
+		}
+
+		s.mu.Lock()
+		if next > s.committed {
+			s.committed = next
+		}
+		if gap != nil && gap.Prev == s.committed {
+			s.maybeFill(*gap)
+		}
+		committed = s.committed
+		s.mu.Unlock()
+
+		if gap != nil || len(ids) < eventsBatch {
+			return committed, nil
+		}
+	}
+}
+
+// getFirstGap returns the first gap in the events after prev up to and including to,
+// or false if all those events exist.
+func getFirstGap(ctx context.Context, dbc *sql.DB, prev, to int64) (rsql.Gap, bool, error) {
+	var n int64
+	err := dbc.QueryRowContext(ctx, "select count(*) from events where id>? and id<=?",
+		prev, to).Scan(&n)
+	if err != nil {
+		return rsql.Gap{}, false, err
+	} else if n == to-prev {
+		return rsql.Gap{}, true, nil
+	}
+
+	for prev < to {
+		ids, err := getNextEventIDs(ctx, dbc, prev, 0)
+		if err != nil {
+			return rsql.Gap{}, false, err
+		}
+
+		for _, id := range ids {
+			if id != prev+1 {
+				return rsql.Gap{Prev: prev, Next: id}, false, nil
+			}
+			prev = id
+		}
+
+		if len(ids) < eventsBatch {
+			break
+		}
+	}
+
+	return rsql.Gap{}, true, nil
+}
+
+// maybeFill fills the gap asynchronously unless a gap is already being filled.
+// The mutex must be held.
+func (s *PrefixStreamer) maybeFill(gap rsql.Gap) {
+	if s.filling {
+		return
+	}
+	s.filling = true
+
+	go func() {
+		ctx := context.Background()
+		err := fillGap(ctx, s.wdbc, gap)
+		if err != nil {
+			// ReturnNoErr: The gap is detected and filled again by the next stream query.
+			log.Error(ctx, err)
+		}
+
+		s.mu.Lock()
+		s.filling = false
+		s.mu.Unlock()
+
+		s.notifier.Notify()
+	}()
+}
+
+// prefixStream is a reflex stream client of committed events with keys matching a prefix.
+type prefixStream struct {
+	s      *PrefixStreamer
+	ctx    context.Context
+	prefix string
+	prev   int64
+	opts   reflex.StreamOptions
+	buf    []goku.WatchEvent
+}
+
+// Recv blocks and returns the next event in the stream.
+func (c *prefixStream) Recv() (*reflex.Event, error) {
+	e, err := c.next()
+	if err != nil {
+		return nil, err
+	}
+
+	return &reflex.Event{
+		ID:        strconv.FormatInt(e.Ref, 10),
+		Type:      e.Type,
+		ForeignID: e.Key,
+		Timestamp: e.Timestamp,
+		MetaData:  e.Value,
+	}, nil
+}
+
+// next blocks and returns the next event in the stream. The value is the event metadata as is.
+func (c *prefixStream) next() (goku.WatchEvent, error) {
+	for len(c.buf) == 0 {
+		if err := c.ctx.Err(); err != nil {
+			return goku.WatchEvent{}, err
+		}
+
+		// Get the notify channel before querying to not miss any notifications.
+		notify := c.s.notifier.C()
+
+		committed, err := c.s.getCommitted(c.ctx, c.prev)
+		if err != nil {
+			return goku.WatchEvent{}, err
+		}
+
+		if committed > c.prev {
+			where := "`key` like ? escape '!' and `type`!=0 and id>? and id<=?"
+			args := []interface{}{likePrefix(c.prefix), c.prev, committed}
+			if c.opts.Lag > 0 {
+				where += " and timestamp<?"
+				args = append(args, time.Now().Add(-c.opts.Lag).UTC())
+			}
+
+			c.buf, err = getWatchEventsWhere(c.ctx, c.s.rdbc, where+" order by id asc limit ?", append(args, eventsBatch)...)
+			if err != nil {
+				return goku.WatchEvent{}, err
+			} else if len(c.buf) > 0 {
+				break
+			} else if c.opts.Lag == 0 {
+				// Skip committed events of other keys.
+				c.prev = committed
+			}
+		}
+
+		if c.opts.StreamToHead {
+			return goku.WatchEvent{}, reflex.ErrHeadReached
+		}
+
+		t := time.NewTimer(streamBackoff)
+		select {
+		case <-notify:
+		case <-t.C:
+		case <-c.ctx.Done():
+		}
+		t.Stop()
+	}
+
+	e := c.buf[0]
+	c.buf = c.buf[1:]
+	c.prev = e.Ref
+
+	return e, nil
+}
+
+// watchStream is a stream client of typed events with keys matching a prefix.
+type watchStream struct {
+	c *prefixStream
+}
+
+// Recv blocks and returns the next typed event in the stream.
+func (w *watchStream) Recv() (goku.WatchEvent, error) {
+	e, err := w.c.next()
+	if err != nil {
+		return goku.WatchEvent{}, err
+	}
+
+	if e.Type != goku.EventTypeSet {
+		e.Value = nil
+	}
+
+	return e, nil
+}
+
+const (
+	// eventsBatch is the max number of events (or ids) loaded per query.
+	eventsBatch = 1000
+
+	// streamBackoff is the period prefix streams poll for new events if not notified.
+	streamBackoff = time.Second * 10
+)
+
+// loadNextEvents returns the next events after prev that are older than the lag.
+func loadNextEvents(ctx context.Context, dbc *sql.DB, prev int64, lag time.Duration) ([]*reflex.Event, error) {
+	where, args := nextEventsWhere(prev, lag)
+	return getEventsWhere(ctx, dbc, where, args...)
+}
+
+func getNextEventIDs(ctx context.Context, dbc *sql.DB, prev int64, lag time.Duration) ([]int64, error) {
+	where, args := nextEventsWhere(prev, lag)
+
+	rows, err := dbc.QueryContext(ctx, "select id from events where "+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		res = append(res, id)
+	}
+
+	return res, rows.Err()
+}
+
+// nextEventsWhere returns the where clause (and args) selecting the next batch of events after prev
+// that are older than the lag. The lag cutoff is calculated locally, not via the database clock.
+func nextEventsWhere(prev int64, lag time.Duration) (string, []interface{}) {
+	where := "id>?"
+	args := []interface{}{prev}
+
+	if lag > 0 {
+		where += " and timestamp<?"
+		args = append(args, time.Now().Add(-lag).UTC())
+	}
+
+	return where + " order by id asc limit ?", append(args, eventsBatch)
+}
+
+func getEventsWhere(ctx context.Context, dbc *sql.DB, where string, args ...interface{}) ([]*reflex.Event, error) {
+	rows, err := dbc.QueryContext(ctx, "select id, `key`, timestamp, `type`, metadata "+
+		"from events where "+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*reflex.Event
+	for rows.Next() {
+		var (
+			e   reflex.Event
+			id  int64
+			typ goku.EventType
+		)
+		err := rows.Scan(&id, &e.ForeignID, &e.Timestamp, &typ, &e.MetaData)
+		if err != nil {
+			return nil, err
+		}
+		e.ID = strconv.FormatInt(id, 10)
+		e.Type = typ
+		res = append(res, &e)
+	}
+
+	return res, rows.Err()
+}
+
+// getWatchEventsWhere returns the typed events matching the where clause. The value
+// is the event metadata as is. Note that values of events superseded before the event
+// log compaction ref are empty.
+func getWatchEventsWhere(ctx context.Context, dbc *sql.DB, where string, args ...interface{}) ([]goku.WatchEvent, error) {
+	rows, err := dbc.QueryContext(ctx, "select id, `key`, `type`, timestamp, metadata, version, lease_id "+
+		"from events where "+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []goku.WatchEvent
+	for rows.Next() {
+		var (
+			e       goku.WatchEvent
+			version sql.NullInt64
+			leaseID sql.NullInt64
+		)
+		err := rows.Scan(&e.Ref, &e.Key, &e.Type, &e.Timestamp, &e.Value, &version, &leaseID)
+		if err != nil {
+			return nil, err
+		}
+		e.Version = version.Int64
+		e.LeaseID = leaseID.Int64
+		res = append(res, e)
+	}
+
+	return res, rows.Err()
+}
+
+// likePrefix returns a LIKE pattern matching strings with the prefix. Wildcard characters
+// in the prefix are escaped with "!", so the pattern must be used with "escape '!'". A backslash
+// is not used since it requires escaping in some sql dialects' string literals, but not in others.
+func likePrefix(prefix string) string {
+	r := strings.NewReplacer(`!`, `!!`, `%`, `!%`, `_`, `!_`)
+	return r.Replace(prefix) + "%"
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/jtest"
+	"github.com/luno/reflex"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixStreamer(t *testing.T) {
+	ctx := context.Background()
+	dbc := ConnectForTesting(t)
+
+	for _, key := range []string{"a/1", "b/1", "a_1", "a/2", "b/2"} {
+		err := Set(ctx, dbc, SetReq{Key: key})
+		jtest.RequireNil(t, err)
+	}
+
+	// Rollback an event to create a gap.
+	tx, err := dbc.Begin()
+	jtest.RequireNil(t, err)
+	_, err = insertEvent(ctx, tx, getDriver(dbc), "a/3", goku.EventTypeSet, nil, 1, 0)
+	jtest.RequireNil(t, err)
+	jtest.RequireNil(t, tx.Rollback())
+
+	err = Set(ctx, dbc, SetReq{Key: "a/4"})
+	jtest.RequireNil(t, err)
+
+	s := NewPrefixStreamer(dbc, dbc, nil)
+
+	streamKeys := func(t *testing.T, prefix string) []string {
+		sc, err := s.Stream(prefix)(ctx, "", reflex.WithStreamToHead())
+		jtest.RequireNil(t, err)
+
+		var res []string
+		for {
+			e, err := sc.Recv()
+			if reflex.IsHeadReachedErr(err) {
+				return res
+			}
+			jtest.RequireNil(t, err)
+			res = append(res, e.ForeignID)
+		}
+	}
+
+	assertKeys := func(t *testing.T, prefix string, keys ...string) {
+		t.Helper()
+
+		// Streams block at the gap until it is filled asynchronously.
+		require.Eventually(t, func() bool {
+			return reflect.DeepEqual(keys, streamKeys(t, prefix))
+		}, time.Second*5, time.Millisecond*10)
+	}
+
+	assertKeys(t, "a/", "a/1", "a/2", "a/4")
+	assertKeys(t, "a_", "a_1")
+	assertKeys(t, "b", "b/1", "b/2")
+	assertKeys(t, "", "a/1", "b/1", "a_1", "a/2", "b/2", "a/4")
+}
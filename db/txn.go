@@ -0,0 +1,145 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+)
+
+type TxnReq struct {
+	Compares []goku.Compare
+	Then     []goku.Op
+	Else     []goku.Op
+}
+
+// Txn executes the then operations if all the compares are true, otherwise the else operations.
+// All compares are evaluated and the rows of compared keys are locked for the duration of the
+// transaction. Compared keys without rows are not locked, instead they are checked again with a
+// locking read after the operations; if such a key was created concurrently in the meantime, the
+// transaction fails with goku.ErrUpdateRace.
+func Txn(ctx context.Context, dbc *sql.DB, req TxnReq) (goku.TxnResponse, error) {
+	tx, err := dbc.Begin()
+	if err != nil {
+		return goku.TxnResponse{}, err
+	}
+	defer tx.Rollback()
+
+	d := getDriver(dbc)
+
+	succeeded := true
+	missing := make(map[string]bool)
+	for _, c := range req.Compares {
+		ok, exists, err := compareTx(ctx, tx, d, c)
+		if err != nil {
+			return goku.TxnResponse{}, err
+		} else if !ok {
+			succeeded = false
+		}
+
+		if !exists {
+			missing[c.Key] = true
+		}
+	}
+
+	ops := req.Then
+	if !succeeded {
+		ops = req.Else
+	}
+
+	resp := goku.TxnResponse{Succeeded: succeeded}
+	var notify bool
+	for _, op := range ops {
+		switch op.Type {
+		case goku.OpTypeGet:
+			kv, err := lookupWhere(ctx, tx, "`key`=? and deleted_ref is null", op.Key)
+			if errors.Is(err, goku.ErrNotFound) {
+				// Return zero KV
+			} else if err != nil {
+				return goku.TxnResponse{}, err
+			}
+			resp.Gets = append(resp.Gets, kv)
+		case goku.OpTypeSet:
+			err := setTx(ctx, tx, d, SetReq{
+				Key:         op.Key,
+				Value:       op.Value,
+				LeaseID:     op.SetOptions.LeaseID,
+				ExpiresAt:   op.SetOptions.ExpiresAt,
+				PrevVersion: op.SetOptions.PrevVersion,
+				CreateOnly:  op.SetOptions.CreateOnly,
+			})
+			if err != nil {
+				return goku.TxnResponse{}, err
+			}
+			// Concurrent creates of the key fail the insert.
+			delete(missing, op.Key)
+			notify = true
+		case goku.OpTypeDelete:
+			_, err := deleteTx(ctx, tx, d, DeleteReq{
+				Key:         op.Key,
+				PrevVersion: op.DeleteOptions.PrevVersion,
+				LeaseID:     op.DeleteOptions.LeaseID,
+			})
+			if err != nil {
+				return goku.TxnResponse{}, err
+			}
+			notify = true
+		default:
+			return goku.TxnResponse{}, errors.New("invalid op type", j.KV("type", op.Type))
+		}
+	}
+
+	for key := range missing {
+		_, err := lookupWhere(ctx, tx, "`key`=?"+d.ForUpdate(), key)
+		if errors.Is(err, goku.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return goku.TxnResponse{}, err
+		}
+
+		return goku.TxnResponse{}, errors.Wrap(goku.ErrUpdateRace, "compared key created", j.KV("key", key))
+	}
+
+	if notify {
+		defer notifier.Notify()
+	}
+
+	return resp, tx.Commit()
+}
+
+// compareTx returns true if the compare predicate is true for the current state of the key and
+// whether the key has a row. It locks the row of the key (if any) until the transaction completes,
+// see lookupLocked.
+func compareTx(ctx context.Context, tx *sql.Tx, d Driver, c goku.Compare) (bool, bool, error) {
+	kv, err := lookupLocked(ctx, tx, d, "`key`=?", c.Key)
+	exists := err == nil
+	if errors.Is(err, goku.ErrNotFound) {
+		// Compare zero KV
+	} else if err != nil {
+		return false, false, err
+	} else if kv.DeletedRef != 0 {
+		// Compare deleted key-values as zero KV
+		kv = goku.KV{}
+	}
+
+	ok, err := compare(kv, c)
+	return ok, exists, err
+}
+
+// compare returns true if the compare predicate is true for the key-value.
+func compare(kv goku.KV, c goku.Compare) (bool, error) {
+	switch c.Type {
+	case goku.CompareTypeVersion:
+		return kv.Version == c.Value, nil
+	case goku.CompareTypeCreatedRef:
+		return kv.CreatedRef == c.Value, nil
+	case goku.CompareTypeExists:
+		return (kv.Version > 0) == (c.Value != 0), nil
+	case goku.CompareTypeLeaseID:
+		return kv.LeaseID == c.Value, nil
+	default:
+		return false, errors.New("invalid compare type", j.KV("type", c.Type))
+	}
+}
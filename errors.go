@@ -6,9 +6,12 @@ import (
 )
 
 var (
-	ErrUpdateRace    = errors.New("update failed due to data race", j.C("ERR_021c218d3d627915")) // Concurrent sets can cause race errors, can just try again.
-	ErrNotFound      = errors.New("key not found", j.C("ERR_1c1777690f774c97"))
-	ErrLeaseNotFound = errors.New("lease not found", j.C("ERR_235d9b7679294c92"))
-	ErrInvalidKey    = errors.New("invalid key", j.C("ERR_75bca259ff56586e"))
-	ErrConditional   = errors.New("conditional update failed", j.C("ERR_3a315c1fe3a73d55"))
+	ErrUpdateRace           = errors.New("update failed due to data race", j.C("ERR_021c218d3d627915")) // Concurrent sets can cause race errors, can just try again.
+	ErrNotFound             = errors.New("key not found", j.C("ERR_1c1777690f774c97"))
+	ErrLeaseNotFound        = errors.New("lease not found", j.C("ERR_235d9b7679294c92"))
+	ErrInvalidKey           = errors.New("invalid key", j.C("ERR_75bca259ff56586e"))
+	ErrConditional          = errors.New("conditional update failed", j.C("ERR_3a315c1fe3a73d55"))
+	ErrCompacted            = errors.New("events compacted", j.C("ERR_ed71309703b7c1b9")) // Requested ref is before the event log compaction point.
+	ErrNotInteger           = errors.New("value not an integer", j.C("ERR_f985b41b043167ef"))
+	ErrEphemeralUnsupported = errors.New("ephemeral keys not supported", j.C("ERR_5110a9db57e389a1")) // Only grpc clients support ephemeral keys.
 )
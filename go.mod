@@ -6,8 +6,11 @@ require (
 	github.com/corverroos/truss v0.0.0-20200921045746-e25f1f150ad4
 	github.com/go-sql-driver/mysql v1.5.0
 	github.com/golang/protobuf v1.3.2
+	github.com/lib/pq v1.10.9
 	github.com/luno/jettison v0.0.0-20200903122533-19ed5345d220
 	github.com/luno/reflex v0.0.0-20200901152915-49bb379a4d1e
+	github.com/mattn/go-sqlite3 v1.14.6
+	github.com/prometheus/client_golang v1.1.0
 	github.com/stretchr/testify v1.6.0
 	google.golang.org/grpc v1.24.0
 )
@@ -184,7 +184,13 @@ func (m *GetRequest) GetKey() string {
 }
 
 type ListRequest struct {
-	Prefix               string   `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Prefix string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Options
+	Limit                int64    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	StartAfter           string   `protobuf:"bytes,3,opt,name=start_after,json=startAfter,proto3" json:"start_after,omitempty"`
+	EndKey               string   `protobuf:"bytes,4,opt,name=end_key,json=endKey,proto3" json:"end_key,omitempty"`
+	Reverse              bool     `protobuf:"varint,5,opt,name=reverse,proto3" json:"reverse,omitempty"`
+	KeysOnly             bool     `protobuf:"varint,6,opt,name=keys_only,json=keysOnly,proto3" json:"keys_only,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -222,6 +228,41 @@ func (m *ListRequest) GetPrefix() string {
 	return ""
 }
 
+func (m *ListRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListRequest) GetStartAfter() string {
+	if m != nil {
+		return m.StartAfter
+	}
+	return ""
+}
+
+func (m *ListRequest) GetEndKey() string {
+	if m != nil {
+		return m.EndKey
+	}
+	return ""
+}
+
+func (m *ListRequest) GetReverse() bool {
+	if m != nil {
+		return m.Reverse
+	}
+	return false
+}
+
+func (m *ListRequest) GetKeysOnly() bool {
+	if m != nil {
+		return m.KeysOnly
+	}
+	return false
+}
+
 type ListResponse struct {
 	Kvs                  []*KV    `protobuf:"bytes,1,rep,name=kvs,proto3" json:"kvs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -262,7 +303,11 @@ func (m *ListResponse) GetKvs() []*KV {
 }
 
 type DeleteRequest struct {
-	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Options
+	PrevVersion          int64    `protobuf:"varint,2,opt,name=prev_version,json=prevVersion,proto3" json:"prev_version,omitempty"`
+	LeaseId              int64    `protobuf:"varint,3,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+	ReturnPrev           bool     `protobuf:"varint,4,opt,name=return_prev,json=returnPrev,proto3" json:"return_prev,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -300,6 +345,66 @@ func (m *DeleteRequest) GetKey() string {
 	return ""
 }
 
+func (m *DeleteRequest) GetPrevVersion() int64 {
+	if m != nil {
+		return m.PrevVersion
+	}
+	return 0
+}
+
+func (m *DeleteRequest) GetLeaseId() int64 {
+	if m != nil {
+		return m.LeaseId
+	}
+	return 0
+}
+
+func (m *DeleteRequest) GetReturnPrev() bool {
+	if m != nil {
+		return m.ReturnPrev
+	}
+	return false
+}
+
+type DeleteResponse struct {
+	Prev                 *KV      `protobuf:"bytes,1,opt,name=prev,proto3" json:"prev,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteResponse) Reset()         { *m = DeleteResponse{} }
+func (m *DeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteResponse) ProtoMessage()    {}
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{6}
+}
+
+func (m *DeleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteResponse.Unmarshal(m, b)
+}
+func (m *DeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteResponse.Merge(m, src)
+}
+func (m *DeleteResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteResponse.Size(m)
+}
+func (m *DeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteResponse proto.InternalMessageInfo
+
+func (m *DeleteResponse) GetPrev() *KV {
+	if m != nil {
+		return m.Prev
+	}
+	return nil
+}
+
 type SetRequest struct {
 	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -308,6 +413,7 @@ type SetRequest struct {
 	LeaseId              int64                `protobuf:"varint,4,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
 	PrevVersion          int64                `protobuf:"varint,5,opt,name=prev_version,json=prevVersion,proto3" json:"prev_version,omitempty"`
 	CreateOnly           bool                 `protobuf:"varint,6,opt,name=create_only,json=createOnly,proto3" json:"create_only,omitempty"`
+	Ephemeral            bool                 `protobuf:"varint,7,opt,name=ephemeral,proto3" json:"ephemeral,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -317,7 +423,7 @@ func (m *SetRequest) Reset()         { *m = SetRequest{} }
 func (m *SetRequest) String() string { return proto.CompactTextString(m) }
 func (*SetRequest) ProtoMessage()    {}
 func (*SetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_34ec642ad405eef9, []int{6}
+	return fileDescriptor_34ec642ad405eef9, []int{7}
 }
 
 func (m *SetRequest) XXX_Unmarshal(b []byte) error {
@@ -380,6 +486,13 @@ func (m *SetRequest) GetCreateOnly() bool {
 	return false
 }
 
+func (m *SetRequest) GetEphemeral() bool {
+	if m != nil {
+		return m.Ephemeral
+	}
+	return false
+}
+
 type StreamRequest struct {
 	Prefix               string                  `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
 	Req                  *reflexpb.StreamRequest `protobuf:"bytes,2,opt,name=req,proto3" json:"req,omitempty"`
@@ -392,7 +505,7 @@ func (m *StreamRequest) Reset()         { *m = StreamRequest{} }
 func (m *StreamRequest) String() string { return proto.CompactTextString(m) }
 func (*StreamRequest) ProtoMessage()    {}
 func (*StreamRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_34ec642ad405eef9, []int{7}
+	return fileDescriptor_34ec642ad405eef9, []int{8}
 }
 
 func (m *StreamRequest) XXX_Unmarshal(b []byte) error {
@@ -439,7 +552,7 @@ func (m *UpdateLeaseRequest) Reset()         { *m = UpdateLeaseRequest{} }
 func (m *UpdateLeaseRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateLeaseRequest) ProtoMessage()    {}
 func (*UpdateLeaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_34ec642ad405eef9, []int{8}
+	return fileDescriptor_34ec642ad405eef9, []int{9}
 }
 
 func (m *UpdateLeaseRequest) XXX_Unmarshal(b []byte) error {
@@ -485,7 +598,7 @@ func (m *ExpireLeaseRequest) Reset()         { *m = ExpireLeaseRequest{} }
 func (m *ExpireLeaseRequest) String() string { return proto.CompactTextString(m) }
 func (*ExpireLeaseRequest) ProtoMessage()    {}
 func (*ExpireLeaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_34ec642ad405eef9, []int{9}
+	return fileDescriptor_34ec642ad405eef9, []int{10}
 }
 
 func (m *ExpireLeaseRequest) XXX_Unmarshal(b []byte) error {
@@ -513,234 +626,2028 @@ func (m *ExpireLeaseRequest) GetLeaseId() int64 {
 	return 0
 }
 
-func init() {
-	proto.RegisterType((*Empty)(nil), "gokupb.Empty")
-	proto.RegisterType((*KV)(nil), "gokupb.KV")
-	proto.RegisterType((*GetRequest)(nil), "gokupb.GetRequest")
-	proto.RegisterType((*ListRequest)(nil), "gokupb.ListRequest")
-	proto.RegisterType((*ListResponse)(nil), "gokupb.ListResponse")
-	proto.RegisterType((*DeleteRequest)(nil), "gokupb.DeleteRequest")
-	proto.RegisterType((*SetRequest)(nil), "gokupb.SetRequest")
-	proto.RegisterType((*StreamRequest)(nil), "gokupb.StreamRequest")
-	proto.RegisterType((*UpdateLeaseRequest)(nil), "gokupb.UpdateLeaseRequest")
-	proto.RegisterType((*ExpireLeaseRequest)(nil), "gokupb.ExpireLeaseRequest")
+type Compare struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Type                 int32    `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
+	Value                int64    `protobuf:"varint,3,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("goku.proto", fileDescriptor_34ec642ad405eef9) }
-
-var fileDescriptor_34ec642ad405eef9 = []byte{
-	// 558 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0xad, 0xe3, 0xc4, 0x69, 0xc7, 0x89, 0x40, 0xcb, 0x97, 0xb1, 0x10, 0x4d, 0x2d, 0x21, 0x02,
-	0x54, 0x4e, 0x15, 0xb8, 0xd0, 0x1b, 0x12, 0x51, 0x84, 0x5a, 0x09, 0xc9, 0x81, 0x5c, 0x23, 0xa7,
-	0x99, 0x04, 0x13, 0xc7, 0xeb, 0xae, 0xd7, 0x51, 0xf2, 0xff, 0x38, 0xf3, 0x3b, 0xf8, 0x19, 0xc8,
-	0xbb, 0xde, 0xda, 0xc6, 0x2a, 0xd0, 0x93, 0x77, 0x66, 0xdf, 0xec, 0xcc, 0xbc, 0xf7, 0x0c, 0xb0,
-	0xa2, 0xeb, 0xd4, 0x8d, 0x19, 0xe5, 0x94, 0x18, 0xd9, 0x39, 0x9e, 0xdb, 0xa7, 0xab, 0x80, 0x7f,
-	0x4b, 0xe7, 0xee, 0x15, 0xdd, 0x0c, 0xc2, 0x34, 0xa2, 0x03, 0x86, 0xcb, 0x10, 0x77, 0xf9, 0x27,
-	0x9e, 0xe7, 0x07, 0x59, 0x65, 0x1f, 0xaf, 0x28, 0x5d, 0x85, 0x38, 0x10, 0xd1, 0x3c, 0x5d, 0x0e,
-	0x78, 0xb0, 0xc1, 0x84, 0xfb, 0x9b, 0x58, 0x02, 0x9c, 0x36, 0xb4, 0x46, 0x9b, 0x98, 0xef, 0x9d,
-	0x1f, 0x1a, 0x34, 0x2e, 0xa6, 0xe4, 0x3e, 0xe8, 0x6b, 0xdc, 0x5b, 0x5a, 0x4f, 0xeb, 0x1f, 0x79,
-	0xd9, 0x91, 0x3c, 0x84, 0xd6, 0xd6, 0x0f, 0x53, 0xb4, 0x1a, 0x3d, 0xad, 0xdf, 0xf1, 0x64, 0x40,
-	0x2c, 0x68, 0x6f, 0x91, 0x25, 0x01, 0x8d, 0x2c, 0xbd, 0xa7, 0xf5, 0x75, 0x4f, 0x85, 0xe4, 0x18,
-	0xcc, 0x2b, 0x86, 0x3e, 0xc7, 0xc5, 0x8c, 0xe1, 0xd2, 0x6a, 0x8a, 0x5b, 0xc8, 0x53, 0x1e, 0x2e,
-	0x33, 0x40, 0x1a, 0x2f, 0x6e, 0x00, 0x2d, 0x09, 0xc8, 0x53, 0x39, 0x60, 0x81, 0x21, 0x2a, 0x80,
-	0x21, 0x01, 0x79, 0x2a, 0x03, 0x3c, 0x85, 0xc3, 0x10, 0xfd, 0x04, 0x67, 0xc1, 0xc2, 0x6a, 0xcb,
-	0xee, 0x22, 0xfe, 0xb4, 0x70, 0x9e, 0x03, 0x8c, 0x91, 0x7b, 0x78, 0x9d, 0x62, 0xc2, 0xeb, 0xdb,
-	0x38, 0x2f, 0xc0, 0xbc, 0x0c, 0x92, 0x1b, 0xc0, 0x63, 0x30, 0x62, 0x86, 0xcb, 0x60, 0x97, 0x63,
-	0xf2, 0xc8, 0x39, 0x85, 0x8e, 0x84, 0x25, 0x31, 0x8d, 0x12, 0x24, 0xcf, 0x40, 0x5f, 0x6f, 0x13,
-	0x4b, 0xeb, 0xe9, 0x7d, 0x73, 0x08, 0xae, 0xd4, 0xc2, 0xbd, 0x98, 0x7a, 0x59, 0xda, 0x39, 0x81,
-	0xee, 0x47, 0x31, 0xdd, 0xed, 0x7d, 0x7f, 0x6a, 0x00, 0x93, 0xbf, 0x0c, 0x76, 0x0b, 0xcd, 0xef,
-	0x01, 0x70, 0x17, 0x07, 0x0c, 0x93, 0x99, 0xcf, 0x05, 0xd3, 0xe6, 0xd0, 0x76, 0xa5, 0xa8, 0xae,
-	0x12, 0xd5, 0xfd, 0xa2, 0x44, 0xf5, 0x8e, 0x72, 0xf4, 0x07, 0x5e, 0x21, 0xa9, 0x59, 0x21, 0x89,
-	0x9c, 0x40, 0x27, 0x66, 0xb8, 0x9d, 0x29, 0x05, 0xa5, 0x04, 0x66, 0x96, 0x9b, 0xfe, 0xa9, 0xe2,
-	0x8c, 0x46, 0xe1, 0x5e, 0x68, 0x70, 0xa8, 0x54, 0xfc, 0x1c, 0x85, 0x7b, 0xc7, 0x83, 0xee, 0x84,
-	0x33, 0xf4, 0x37, 0xff, 0xa0, 0x92, 0xbc, 0x02, 0x9d, 0xe1, 0xb5, 0x58, 0xcb, 0x1c, 0x3e, 0x71,
-	0x95, 0x4f, 0xdd, 0x4a, 0xb5, 0x97, 0x61, 0x9c, 0xef, 0x40, 0xbe, 0x0a, 0x1b, 0x5c, 0x66, 0x83,
-	0xaa, 0x87, 0xcb, 0x8b, 0x68, 0xd5, 0x45, 0xaa, 0xf4, 0x34, 0xee, 0x40, 0x8f, 0x33, 0x00, 0x32,
-	0x12, 0xc1, 0x7f, 0xf6, 0x1a, 0xfe, 0x6a, 0x40, 0x73, 0x4c, 0xd7, 0x29, 0x79, 0x09, 0xfa, 0x18,
-	0x39, 0x21, 0xca, 0x05, 0x85, 0xdf, 0xec, 0x92, 0x33, 0x9c, 0x03, 0xf2, 0x06, 0x9a, 0x99, 0x89,
-	0xc8, 0x03, 0x95, 0x2d, 0x39, 0xaf, 0x0a, 0x3d, 0xd3, 0xc8, 0x6b, 0xd0, 0x27, 0xe5, 0x57, 0x0b,
-	0xb3, 0xd8, 0x5d, 0x95, 0x93, 0x7f, 0xea, 0x01, 0x39, 0x03, 0x43, 0xfa, 0x8d, 0x3c, 0x52, 0x57,
-	0x15, 0xff, 0xd5, 0x2b, 0xde, 0x81, 0x21, 0xf9, 0x2e, 0x2a, 0x2a, 0xfc, 0xdb, 0xf7, 0x0a, 0x61,
-	0x46, 0x5b, 0x8c, 0xb8, 0x98, 0xe9, 0x1c, 0xcc, 0x92, 0x1e, 0xc4, 0x56, 0xa5, 0x75, 0x91, 0xea,
-	0x1d, 0xcf, 0xc1, 0x2c, 0xf1, 0x5b, 0xd4, 0xd6, 0x49, 0xaf, 0xd5, 0xce, 0x0d, 0x21, 0xdd, 0xdb,
-	0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x9e, 0xb8, 0xd0, 0x59, 0x00, 0x05, 0x00, 0x00,
+func (m *Compare) Reset()         { *m = Compare{} }
+func (m *Compare) String() string { return proto.CompactTextString(m) }
+func (*Compare) ProtoMessage()    {}
+func (*Compare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{11}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// GokuClient is the client API for Goku service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type GokuClient interface {
-	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*KV, error)
-	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (Goku_ListClient, error)
-	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*Empty, error)
-	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error)
-	Stream(ctx context.Context, in *StreamRequest, opts ...grpc.CallOption) (Goku_StreamClient, error)
-	UpdateLease(ctx context.Context, in *UpdateLeaseRequest, opts ...grpc.CallOption) (*Empty, error)
-	ExpireLease(ctx context.Context, in *ExpireLeaseRequest, opts ...grpc.CallOption) (*Empty, error)
+func (m *Compare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Compare.Unmarshal(m, b)
 }
-
-type gokuClient struct {
-	cc *grpc.ClientConn
+func (m *Compare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Compare.Marshal(b, m, deterministic)
 }
-
-func NewGokuClient(cc *grpc.ClientConn) GokuClient {
-	return &gokuClient{cc}
+func (m *Compare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Compare.Merge(m, src)
+}
+func (m *Compare) XXX_Size() int {
+	return xxx_messageInfo_Compare.Size(m)
+}
+func (m *Compare) XXX_DiscardUnknown() {
+	xxx_messageInfo_Compare.DiscardUnknown(m)
 }
 
-func (c *gokuClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*KV, error) {
-	out := new(KV)
-	err := c.cc.Invoke(ctx, "/gokupb.Goku/Get", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_Compare proto.InternalMessageInfo
+
+func (m *Compare) GetKey() string {
+	if m != nil {
+		return m.Key
 	}
-	return out, nil
+	return ""
 }
 
-func (c *gokuClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (Goku_ListClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Goku_serviceDesc.Streams[0], "/gokupb.Goku/List", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &gokuListClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+func (m *Compare) GetType() int32 {
+	if m != nil {
+		return m.Type
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	return 0
+}
+
+func (m *Compare) GetValue() int64 {
+	if m != nil {
+		return m.Value
 	}
-	return x, nil
+	return 0
 }
 
-type Goku_ListClient interface {
-	Recv() (*KV, error)
-	grpc.ClientStream
+// Op defines a txn operation. Only one of the requests should be populated.
+type Op struct {
+	Get                  *GetRequest    `protobuf:"bytes,1,opt,name=get,proto3" json:"get,omitempty"`
+	Set                  *SetRequest    `protobuf:"bytes,2,opt,name=set,proto3" json:"set,omitempty"`
+	Delete               *DeleteRequest `protobuf:"bytes,3,opt,name=delete,proto3" json:"delete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-type gokuListClient struct {
-	grpc.ClientStream
+func (m *Op) Reset()         { *m = Op{} }
+func (m *Op) String() string { return proto.CompactTextString(m) }
+func (*Op) ProtoMessage()    {}
+func (*Op) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{12}
 }
 
-func (x *gokuListClient) Recv() (*KV, error) {
-	m := new(KV)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *Op) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Op.Unmarshal(m, b)
+}
+func (m *Op) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Op.Marshal(b, m, deterministic)
+}
+func (m *Op) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Op.Merge(m, src)
+}
+func (m *Op) XXX_Size() int {
+	return xxx_messageInfo_Op.Size(m)
+}
+func (m *Op) XXX_DiscardUnknown() {
+	xxx_messageInfo_Op.DiscardUnknown(m)
 }
 
-func (c *gokuClient) Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/gokupb.Goku/Set", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_Op proto.InternalMessageInfo
+
+func (m *Op) GetGet() *GetRequest {
+	if m != nil {
+		return m.Get
 	}
-	return out, nil
+	return nil
 }
 
-func (c *gokuClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/gokupb.Goku/Delete", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Op) GetSet() *SetRequest {
+	if m != nil {
+		return m.Set
 	}
-	return out, nil
+	return nil
 }
 
-func (c *gokuClient) Stream(ctx context.Context, in *StreamRequest, opts ...grpc.CallOption) (Goku_StreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Goku_serviceDesc.Streams[1], "/gokupb.Goku/Stream", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &gokuStreamClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *Op) GetDelete() *DeleteRequest {
+	if m != nil {
+		return m.Delete
 	}
-	return x, nil
+	return nil
 }
 
-type Goku_StreamClient interface {
-	Recv() (*reflexpb.Event, error)
-	grpc.ClientStream
+type TxnRequest struct {
+	Compares             []*Compare `protobuf:"bytes,1,rep,name=compares,proto3" json:"compares,omitempty"`
+	Then                 []*Op      `protobuf:"bytes,2,rep,name=then,proto3" json:"then,omitempty"`
+	Else                 []*Op      `protobuf:"bytes,3,rep,name=else,proto3" json:"else,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
-type gokuStreamClient struct {
-	grpc.ClientStream
+func (m *TxnRequest) Reset()         { *m = TxnRequest{} }
+func (m *TxnRequest) String() string { return proto.CompactTextString(m) }
+func (*TxnRequest) ProtoMessage()    {}
+func (*TxnRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{13}
 }
 
-func (x *gokuStreamClient) Recv() (*reflexpb.Event, error) {
-	m := new(reflexpb.Event)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *TxnRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxnRequest.Unmarshal(m, b)
+}
+func (m *TxnRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxnRequest.Marshal(b, m, deterministic)
+}
+func (m *TxnRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxnRequest.Merge(m, src)
+}
+func (m *TxnRequest) XXX_Size() int {
+	return xxx_messageInfo_TxnRequest.Size(m)
+}
+func (m *TxnRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxnRequest.DiscardUnknown(m)
 }
 
-func (c *gokuClient) UpdateLease(ctx context.Context, in *UpdateLeaseRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/gokupb.Goku/UpdateLease", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_TxnRequest proto.InternalMessageInfo
+
+func (m *TxnRequest) GetCompares() []*Compare {
+	if m != nil {
+		return m.Compares
 	}
-	return out, nil
+	return nil
 }
 
-func (c *gokuClient) ExpireLease(ctx context.Context, in *ExpireLeaseRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/gokupb.Goku/ExpireLease", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *TxnRequest) GetThen() []*Op {
+	if m != nil {
+		return m.Then
 	}
-	return out, nil
+	return nil
 }
 
-// GokuServer is the server API for Goku service.
-type GokuServer interface {
-	Get(context.Context, *GetRequest) (*KV, error)
-	List(*ListRequest, Goku_ListServer) error
-	Set(context.Context, *SetRequest) (*Empty, error)
-	Delete(context.Context, *DeleteRequest) (*Empty, error)
-	Stream(*StreamRequest, Goku_StreamServer) error
-	UpdateLease(context.Context, *UpdateLeaseRequest) (*Empty, error)
-	ExpireLease(context.Context, *ExpireLeaseRequest) (*Empty, error)
+func (m *TxnRequest) GetElse() []*Op {
+	if m != nil {
+		return m.Else
+	}
+	return nil
 }
 
-// UnimplementedGokuServer can be embedded to have forward compatible implementations.
-type UnimplementedGokuServer struct {
+type TxnResponse struct {
+	Succeeded            bool     `protobuf:"varint,1,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Gets                 []*KV    `protobuf:"bytes,2,rep,name=gets,proto3" json:"gets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (*UnimplementedGokuServer) Get(ctx context.Context, req *GetRequest) (*KV, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+func (m *TxnResponse) Reset()         { *m = TxnResponse{} }
+func (m *TxnResponse) String() string { return proto.CompactTextString(m) }
+func (*TxnResponse) ProtoMessage()    {}
+func (*TxnResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{14}
 }
-func (*UnimplementedGokuServer) List(req *ListRequest, srv Goku_ListServer) error {
-	return status.Errorf(codes.Unimplemented, "method List not implemented")
+
+func (m *TxnResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxnResponse.Unmarshal(m, b)
 }
-func (*UnimplementedGokuServer) Set(ctx context.Context, req *SetRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
+func (m *TxnResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxnResponse.Marshal(b, m, deterministic)
 }
-func (*UnimplementedGokuServer) Delete(ctx context.Context, req *DeleteRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+func (m *TxnResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxnResponse.Merge(m, src)
 }
-func (*UnimplementedGokuServer) Stream(req *StreamRequest, srv Goku_StreamServer) error {
-	return status.Errorf(codes.Unimplemented, "method Stream not implemented")
+func (m *TxnResponse) XXX_Size() int {
+	return xxx_messageInfo_TxnResponse.Size(m)
 }
-func (*UnimplementedGokuServer) UpdateLease(ctx context.Context, req *UpdateLeaseRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateLease not implemented")
+func (m *TxnResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxnResponse.DiscardUnknown(m)
 }
-func (*UnimplementedGokuServer) ExpireLease(ctx context.Context, req *ExpireLeaseRequest) (*Empty, error) {
+
+var xxx_messageInfo_TxnResponse proto.InternalMessageInfo
+
+func (m *TxnResponse) GetSucceeded() bool {
+	if m != nil {
+		return m.Succeeded
+	}
+	return false
+}
+
+func (m *TxnResponse) GetGets() []*KV {
+	if m != nil {
+		return m.Gets
+	}
+	return nil
+}
+
+type GetAtRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Ref                  int64    `protobuf:"varint,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAtRequest) Reset()         { *m = GetAtRequest{} }
+func (m *GetAtRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAtRequest) ProtoMessage()    {}
+func (*GetAtRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{15}
+}
+
+func (m *GetAtRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAtRequest.Unmarshal(m, b)
+}
+func (m *GetAtRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAtRequest.Marshal(b, m, deterministic)
+}
+func (m *GetAtRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAtRequest.Merge(m, src)
+}
+func (m *GetAtRequest) XXX_Size() int {
+	return xxx_messageInfo_GetAtRequest.Size(m)
+}
+func (m *GetAtRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAtRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAtRequest proto.InternalMessageInfo
+
+func (m *GetAtRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *GetAtRequest) GetRef() int64 {
+	if m != nil {
+		return m.Ref
+	}
+	return 0
+}
+
+type ListAtRequest struct {
+	Prefix               string   `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Ref                  int64    `protobuf:"varint,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAtRequest) Reset()         { *m = ListAtRequest{} }
+func (m *ListAtRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAtRequest) ProtoMessage()    {}
+func (*ListAtRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{16}
+}
+
+func (m *ListAtRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAtRequest.Unmarshal(m, b)
+}
+func (m *ListAtRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAtRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAtRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAtRequest.Merge(m, src)
+}
+func (m *ListAtRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAtRequest.Size(m)
+}
+func (m *ListAtRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAtRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAtRequest proto.InternalMessageInfo
+
+func (m *ListAtRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *ListAtRequest) GetRef() int64 {
+	if m != nil {
+		return m.Ref
+	}
+	return 0
+}
+
+type HistoryRequest struct {
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Options
+	Limit                int64    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	AfterRef             int64    `protobuf:"varint,3,opt,name=after_ref,json=afterRef,proto3" json:"after_ref,omitempty"`
+	Reverse              bool     `protobuf:"varint,4,opt,name=reverse,proto3" json:"reverse,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HistoryRequest) Reset()         { *m = HistoryRequest{} }
+func (m *HistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*HistoryRequest) ProtoMessage()    {}
+func (*HistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{17}
+}
+
+func (m *HistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HistoryRequest.Unmarshal(m, b)
+}
+func (m *HistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *HistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistoryRequest.Merge(m, src)
+}
+func (m *HistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_HistoryRequest.Size(m)
+}
+func (m *HistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistoryRequest proto.InternalMessageInfo
+
+func (m *HistoryRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *HistoryRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *HistoryRequest) GetAfterRef() int64 {
+	if m != nil {
+		return m.AfterRef
+	}
+	return 0
+}
+
+func (m *HistoryRequest) GetReverse() bool {
+	if m != nil {
+		return m.Reverse
+	}
+	return false
+}
+
+type Revision struct {
+	Key                  string               `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Type                 int32                `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
+	Ref                  int64                `protobuf:"varint,3,opt,name=ref,proto3" json:"ref,omitempty"`
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Value                []byte               `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Revision) Reset()         { *m = Revision{} }
+func (m *Revision) String() string { return proto.CompactTextString(m) }
+func (*Revision) ProtoMessage()    {}
+func (*Revision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{18}
+}
+
+func (m *Revision) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Revision.Unmarshal(m, b)
+}
+func (m *Revision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Revision.Marshal(b, m, deterministic)
+}
+func (m *Revision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Revision.Merge(m, src)
+}
+func (m *Revision) XXX_Size() int {
+	return xxx_messageInfo_Revision.Size(m)
+}
+func (m *Revision) XXX_DiscardUnknown() {
+	xxx_messageInfo_Revision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Revision proto.InternalMessageInfo
+
+func (m *Revision) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *Revision) GetType() int32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *Revision) GetRef() int64 {
+	if m != nil {
+		return m.Ref
+	}
+	return 0
+}
+
+func (m *Revision) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *Revision) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type Lease struct {
+	Id                   int64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpiresAt            *timestamp.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Version              int64                `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Expired              bool                 `protobuf:"varint,4,opt,name=expired,proto3" json:"expired,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Lease) Reset()         { *m = Lease{} }
+func (m *Lease) String() string { return proto.CompactTextString(m) }
+func (*Lease) ProtoMessage()    {}
+func (*Lease) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{19}
+}
+
+func (m *Lease) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Lease.Unmarshal(m, b)
+}
+func (m *Lease) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Lease.Marshal(b, m, deterministic)
+}
+func (m *Lease) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Lease.Merge(m, src)
+}
+func (m *Lease) XXX_Size() int {
+	return xxx_messageInfo_Lease.Size(m)
+}
+func (m *Lease) XXX_DiscardUnknown() {
+	xxx_messageInfo_Lease.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Lease proto.InternalMessageInfo
+
+func (m *Lease) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Lease) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+func (m *Lease) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Lease) GetExpired() bool {
+	if m != nil {
+		return m.Expired
+	}
+	return false
+}
+
+type GrantLeaseRequest struct {
+	ExpiresAt            *timestamp.Timestamp `protobuf:"bytes,1,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GrantLeaseRequest) Reset()         { *m = GrantLeaseRequest{} }
+func (m *GrantLeaseRequest) String() string { return proto.CompactTextString(m) }
+func (*GrantLeaseRequest) ProtoMessage()    {}
+func (*GrantLeaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{20}
+}
+
+func (m *GrantLeaseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GrantLeaseRequest.Unmarshal(m, b)
+}
+func (m *GrantLeaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GrantLeaseRequest.Marshal(b, m, deterministic)
+}
+func (m *GrantLeaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GrantLeaseRequest.Merge(m, src)
+}
+func (m *GrantLeaseRequest) XXX_Size() int {
+	return xxx_messageInfo_GrantLeaseRequest.Size(m)
+}
+func (m *GrantLeaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GrantLeaseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GrantLeaseRequest proto.InternalMessageInfo
+
+func (m *GrantLeaseRequest) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+type GrantLeaseResponse struct {
+	LeaseId              int64    `protobuf:"varint,1,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GrantLeaseResponse) Reset()         { *m = GrantLeaseResponse{} }
+func (m *GrantLeaseResponse) String() string { return proto.CompactTextString(m) }
+func (*GrantLeaseResponse) ProtoMessage()    {}
+func (*GrantLeaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{21}
+}
+
+func (m *GrantLeaseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GrantLeaseResponse.Unmarshal(m, b)
+}
+func (m *GrantLeaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GrantLeaseResponse.Marshal(b, m, deterministic)
+}
+func (m *GrantLeaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GrantLeaseResponse.Merge(m, src)
+}
+func (m *GrantLeaseResponse) XXX_Size() int {
+	return xxx_messageInfo_GrantLeaseResponse.Size(m)
+}
+func (m *GrantLeaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GrantLeaseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GrantLeaseResponse proto.InternalMessageInfo
+
+func (m *GrantLeaseResponse) GetLeaseId() int64 {
+	if m != nil {
+		return m.LeaseId
+	}
+	return 0
+}
+
+type GetLeaseRequest struct {
+	LeaseId              int64    `protobuf:"varint,1,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetLeaseRequest) Reset()         { *m = GetLeaseRequest{} }
+func (m *GetLeaseRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLeaseRequest) ProtoMessage()    {}
+func (*GetLeaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{22}
+}
+
+func (m *GetLeaseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLeaseRequest.Unmarshal(m, b)
+}
+func (m *GetLeaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLeaseRequest.Marshal(b, m, deterministic)
+}
+func (m *GetLeaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLeaseRequest.Merge(m, src)
+}
+func (m *GetLeaseRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLeaseRequest.Size(m)
+}
+func (m *GetLeaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLeaseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLeaseRequest proto.InternalMessageInfo
+
+func (m *GetLeaseRequest) GetLeaseId() int64 {
+	if m != nil {
+		return m.LeaseId
+	}
+	return 0
+}
+
+type ListLeaseKeysRequest struct {
+	LeaseId              int64    `protobuf:"varint,1,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListLeaseKeysRequest) Reset()         { *m = ListLeaseKeysRequest{} }
+func (m *ListLeaseKeysRequest) String() string { return proto.CompactTextString(m) }
+func (*ListLeaseKeysRequest) ProtoMessage()    {}
+func (*ListLeaseKeysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{23}
+}
+
+func (m *ListLeaseKeysRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListLeaseKeysRequest.Unmarshal(m, b)
+}
+func (m *ListLeaseKeysRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListLeaseKeysRequest.Marshal(b, m, deterministic)
+}
+func (m *ListLeaseKeysRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListLeaseKeysRequest.Merge(m, src)
+}
+func (m *ListLeaseKeysRequest) XXX_Size() int {
+	return xxx_messageInfo_ListLeaseKeysRequest.Size(m)
+}
+func (m *ListLeaseKeysRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListLeaseKeysRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListLeaseKeysRequest proto.InternalMessageInfo
+
+func (m *ListLeaseKeysRequest) GetLeaseId() int64 {
+	if m != nil {
+		return m.LeaseId
+	}
+	return 0
+}
+
+type ListLeasesRequest struct {
+	// Options
+	ExpiresBefore        *timestamp.Timestamp `protobuf:"bytes,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
+	IncludeExpired       bool                 `protobuf:"varint,2,opt,name=include_expired,json=includeExpired,proto3" json:"include_expired,omitempty"`
+	AfterId              int64                `protobuf:"varint,3,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"`
+	Limit                int64                `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ListLeasesRequest) Reset()         { *m = ListLeasesRequest{} }
+func (m *ListLeasesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListLeasesRequest) ProtoMessage()    {}
+func (*ListLeasesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{24}
+}
+
+func (m *ListLeasesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListLeasesRequest.Unmarshal(m, b)
+}
+func (m *ListLeasesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListLeasesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListLeasesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListLeasesRequest.Merge(m, src)
+}
+func (m *ListLeasesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListLeasesRequest.Size(m)
+}
+func (m *ListLeasesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListLeasesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListLeasesRequest proto.InternalMessageInfo
+
+func (m *ListLeasesRequest) GetExpiresBefore() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresBefore
+	}
+	return nil
+}
+
+func (m *ListLeasesRequest) GetIncludeExpired() bool {
+	if m != nil {
+		return m.IncludeExpired
+	}
+	return false
+}
+
+func (m *ListLeasesRequest) GetAfterId() int64 {
+	if m != nil {
+		return m.AfterId
+	}
+	return 0
+}
+
+func (m *ListLeasesRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type CreateRequest struct {
+	Prefix string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Value  []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Options
+	ExpiresAt            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	LeaseId              int64                `protobuf:"varint,4,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+	Ephemeral            bool                 `protobuf:"varint,5,opt,name=ephemeral,proto3" json:"ephemeral,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
+func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRequest) ProtoMessage()    {}
+func (*CreateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{25}
+}
+
+func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRequest.Unmarshal(m, b)
+}
+func (m *CreateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRequest.Merge(m, src)
+}
+func (m *CreateRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateRequest.Size(m)
+}
+func (m *CreateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRequest proto.InternalMessageInfo
+
+func (m *CreateRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *CreateRequest) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *CreateRequest) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+func (m *CreateRequest) GetLeaseId() int64 {
+	if m != nil {
+		return m.LeaseId
+	}
+	return 0
+}
+
+func (m *CreateRequest) GetEphemeral() bool {
+	if m != nil {
+		return m.Ephemeral
+	}
+	return false
+}
+
+type CreateResponse struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateResponse) Reset()         { *m = CreateResponse{} }
+func (m *CreateResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateResponse) ProtoMessage()    {}
+func (*CreateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{26}
+}
+
+func (m *CreateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateResponse.Unmarshal(m, b)
+}
+func (m *CreateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateResponse.Merge(m, src)
+}
+func (m *CreateResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateResponse.Size(m)
+}
+func (m *CreateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateResponse proto.InternalMessageInfo
+
+func (m *CreateResponse) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type IncrementRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Delta                int64    `protobuf:"varint,2,opt,name=delta,proto3" json:"delta,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IncrementRequest) Reset()         { *m = IncrementRequest{} }
+func (m *IncrementRequest) String() string { return proto.CompactTextString(m) }
+func (*IncrementRequest) ProtoMessage()    {}
+func (*IncrementRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{27}
+}
+
+func (m *IncrementRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IncrementRequest.Unmarshal(m, b)
+}
+func (m *IncrementRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IncrementRequest.Marshal(b, m, deterministic)
+}
+func (m *IncrementRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IncrementRequest.Merge(m, src)
+}
+func (m *IncrementRequest) XXX_Size() int {
+	return xxx_messageInfo_IncrementRequest.Size(m)
+}
+func (m *IncrementRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IncrementRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IncrementRequest proto.InternalMessageInfo
+
+func (m *IncrementRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *IncrementRequest) GetDelta() int64 {
+	if m != nil {
+		return m.Delta
+	}
+	return 0
+}
+
+type IncrementResponse struct {
+	Value                int64    `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IncrementResponse) Reset()         { *m = IncrementResponse{} }
+func (m *IncrementResponse) String() string { return proto.CompactTextString(m) }
+func (*IncrementResponse) ProtoMessage()    {}
+func (*IncrementResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{28}
+}
+
+func (m *IncrementResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IncrementResponse.Unmarshal(m, b)
+}
+func (m *IncrementResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IncrementResponse.Marshal(b, m, deterministic)
+}
+func (m *IncrementResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IncrementResponse.Merge(m, src)
+}
+func (m *IncrementResponse) XXX_Size() int {
+	return xxx_messageInfo_IncrementResponse.Size(m)
+}
+func (m *IncrementResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_IncrementResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IncrementResponse proto.InternalMessageInfo
+
+func (m *IncrementResponse) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+type DeletePrefixRequest struct {
+	Prefix string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Options
+	BatchSize            int64    `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeletePrefixRequest) Reset()         { *m = DeletePrefixRequest{} }
+func (m *DeletePrefixRequest) String() string { return proto.CompactTextString(m) }
+func (*DeletePrefixRequest) ProtoMessage()    {}
+func (*DeletePrefixRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{29}
+}
+
+func (m *DeletePrefixRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeletePrefixRequest.Unmarshal(m, b)
+}
+func (m *DeletePrefixRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeletePrefixRequest.Marshal(b, m, deterministic)
+}
+func (m *DeletePrefixRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeletePrefixRequest.Merge(m, src)
+}
+func (m *DeletePrefixRequest) XXX_Size() int {
+	return xxx_messageInfo_DeletePrefixRequest.Size(m)
+}
+func (m *DeletePrefixRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeletePrefixRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeletePrefixRequest proto.InternalMessageInfo
+
+func (m *DeletePrefixRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *DeletePrefixRequest) GetBatchSize() int64 {
+	if m != nil {
+		return m.BatchSize
+	}
+	return 0
+}
+
+type DeletePrefixResponse struct {
+	Deleted              int64    `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeletePrefixResponse) Reset()         { *m = DeletePrefixResponse{} }
+func (m *DeletePrefixResponse) String() string { return proto.CompactTextString(m) }
+func (*DeletePrefixResponse) ProtoMessage()    {}
+func (*DeletePrefixResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{30}
+}
+
+func (m *DeletePrefixResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeletePrefixResponse.Unmarshal(m, b)
+}
+func (m *DeletePrefixResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeletePrefixResponse.Marshal(b, m, deterministic)
+}
+func (m *DeletePrefixResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeletePrefixResponse.Merge(m, src)
+}
+func (m *DeletePrefixResponse) XXX_Size() int {
+	return xxx_messageInfo_DeletePrefixResponse.Size(m)
+}
+func (m *DeletePrefixResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeletePrefixResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeletePrefixResponse proto.InternalMessageInfo
+
+func (m *DeletePrefixResponse) GetDeleted() int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+type GetManyRequest struct {
+	Keys                 []string `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetManyRequest) Reset()         { *m = GetManyRequest{} }
+func (m *GetManyRequest) String() string { return proto.CompactTextString(m) }
+func (*GetManyRequest) ProtoMessage()    {}
+func (*GetManyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{31}
+}
+
+func (m *GetManyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetManyRequest.Unmarshal(m, b)
+}
+func (m *GetManyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetManyRequest.Marshal(b, m, deterministic)
+}
+func (m *GetManyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetManyRequest.Merge(m, src)
+}
+func (m *GetManyRequest) XXX_Size() int {
+	return xxx_messageInfo_GetManyRequest.Size(m)
+}
+func (m *GetManyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetManyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetManyRequest proto.InternalMessageInfo
+
+func (m *GetManyRequest) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+type GetManyResponse struct {
+	Kvs                  []*KV    `protobuf:"bytes,1,rep,name=kvs,proto3" json:"kvs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetManyResponse) Reset()         { *m = GetManyResponse{} }
+func (m *GetManyResponse) String() string { return proto.CompactTextString(m) }
+func (*GetManyResponse) ProtoMessage()    {}
+func (*GetManyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{32}
+}
+
+func (m *GetManyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetManyResponse.Unmarshal(m, b)
+}
+func (m *GetManyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetManyResponse.Marshal(b, m, deterministic)
+}
+func (m *GetManyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetManyResponse.Merge(m, src)
+}
+func (m *GetManyResponse) XXX_Size() int {
+	return xxx_messageInfo_GetManyResponse.Size(m)
+}
+func (m *GetManyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetManyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetManyResponse proto.InternalMessageInfo
+
+func (m *GetManyResponse) GetKvs() []*KV {
+	if m != nil {
+		return m.Kvs
+	}
+	return nil
+}
+
+type SetManyRequest struct {
+	Items                []*SetRequest `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *SetManyRequest) Reset()         { *m = SetManyRequest{} }
+func (m *SetManyRequest) String() string { return proto.CompactTextString(m) }
+func (*SetManyRequest) ProtoMessage()    {}
+func (*SetManyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{33}
+}
+
+func (m *SetManyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetManyRequest.Unmarshal(m, b)
+}
+func (m *SetManyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetManyRequest.Marshal(b, m, deterministic)
+}
+func (m *SetManyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetManyRequest.Merge(m, src)
+}
+func (m *SetManyRequest) XXX_Size() int {
+	return xxx_messageInfo_SetManyRequest.Size(m)
+}
+func (m *SetManyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetManyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetManyRequest proto.InternalMessageInfo
+
+func (m *SetManyRequest) GetItems() []*SetRequest {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type ListSnapshotRequest struct {
+	Prefix               string   `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSnapshotRequest) Reset()         { *m = ListSnapshotRequest{} }
+func (m *ListSnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*ListSnapshotRequest) ProtoMessage()    {}
+func (*ListSnapshotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{34}
+}
+
+func (m *ListSnapshotRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSnapshotRequest.Unmarshal(m, b)
+}
+func (m *ListSnapshotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSnapshotRequest.Marshal(b, m, deterministic)
+}
+func (m *ListSnapshotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSnapshotRequest.Merge(m, src)
+}
+func (m *ListSnapshotRequest) XXX_Size() int {
+	return xxx_messageInfo_ListSnapshotRequest.Size(m)
+}
+func (m *ListSnapshotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSnapshotRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSnapshotRequest proto.InternalMessageInfo
+
+func (m *ListSnapshotRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+// ListSnapshotResponse either contains the snapshot refs (first response) or a key-value (subsequent responses).
+type ListSnapshotResponse struct {
+	Ref                  int64    `protobuf:"varint,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	Pending              []int64  `protobuf:"varint,2,rep,packed,name=pending,proto3" json:"pending,omitempty"`
+	Kv                   *KV      `protobuf:"bytes,3,opt,name=kv,proto3" json:"kv,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSnapshotResponse) Reset()         { *m = ListSnapshotResponse{} }
+func (m *ListSnapshotResponse) String() string { return proto.CompactTextString(m) }
+func (*ListSnapshotResponse) ProtoMessage()    {}
+func (*ListSnapshotResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{35}
+}
+
+func (m *ListSnapshotResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSnapshotResponse.Unmarshal(m, b)
+}
+func (m *ListSnapshotResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSnapshotResponse.Marshal(b, m, deterministic)
+}
+func (m *ListSnapshotResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSnapshotResponse.Merge(m, src)
+}
+func (m *ListSnapshotResponse) XXX_Size() int {
+	return xxx_messageInfo_ListSnapshotResponse.Size(m)
+}
+func (m *ListSnapshotResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSnapshotResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSnapshotResponse proto.InternalMessageInfo
+
+func (m *ListSnapshotResponse) GetRef() int64 {
+	if m != nil {
+		return m.Ref
+	}
+	return 0
+}
+
+func (m *ListSnapshotResponse) GetPending() []int64 {
+	if m != nil {
+		return m.Pending
+	}
+	return nil
+}
+
+func (m *ListSnapshotResponse) GetKv() *KV {
+	if m != nil {
+		return m.Kv
+	}
+	return nil
+}
+
+type WatchRequest struct {
+	Prefix               string                  `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Req                  *reflexpb.StreamRequest `protobuf:"bytes,2,opt,name=req,proto3" json:"req,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *WatchRequest) Reset()         { *m = WatchRequest{} }
+func (m *WatchRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()    {}
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{36}
+}
+
+func (m *WatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchRequest.Unmarshal(m, b)
+}
+func (m *WatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchRequest.Merge(m, src)
+}
+func (m *WatchRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchRequest.Size(m)
+}
+func (m *WatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchRequest proto.InternalMessageInfo
+
+func (m *WatchRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *WatchRequest) GetReq() *reflexpb.StreamRequest {
+	if m != nil {
+		return m.Req
+	}
+	return nil
+}
+
+type WatchEvent struct {
+	Key                  string               `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Type                 int32                `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
+	Value                []byte               `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Version              int64                `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	LeaseId              int64                `protobuf:"varint,5,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+	Ref                  int64                `protobuf:"varint,6,opt,name=ref,proto3" json:"ref,omitempty"`
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *WatchEvent) Reset()         { *m = WatchEvent{} }
+func (m *WatchEvent) String() string { return proto.CompactTextString(m) }
+func (*WatchEvent) ProtoMessage()    {}
+func (*WatchEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_34ec642ad405eef9, []int{37}
+}
+
+func (m *WatchEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchEvent.Unmarshal(m, b)
+}
+func (m *WatchEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchEvent.Marshal(b, m, deterministic)
+}
+func (m *WatchEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchEvent.Merge(m, src)
+}
+func (m *WatchEvent) XXX_Size() int {
+	return xxx_messageInfo_WatchEvent.Size(m)
+}
+func (m *WatchEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchEvent proto.InternalMessageInfo
+
+func (m *WatchEvent) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *WatchEvent) GetType() int32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *WatchEvent) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *WatchEvent) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *WatchEvent) GetLeaseId() int64 {
+	if m != nil {
+		return m.LeaseId
+	}
+	return 0
+}
+
+func (m *WatchEvent) GetRef() int64 {
+	if m != nil {
+		return m.Ref
+	}
+	return 0
+}
+
+func (m *WatchEvent) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Empty)(nil), "gokupb.Empty")
+	proto.RegisterType((*KV)(nil), "gokupb.KV")
+	proto.RegisterType((*GetRequest)(nil), "gokupb.GetRequest")
+	proto.RegisterType((*ListRequest)(nil), "gokupb.ListRequest")
+	proto.RegisterType((*ListResponse)(nil), "gokupb.ListResponse")
+	proto.RegisterType((*DeleteRequest)(nil), "gokupb.DeleteRequest")
+	proto.RegisterType((*DeleteResponse)(nil), "gokupb.DeleteResponse")
+	proto.RegisterType((*SetRequest)(nil), "gokupb.SetRequest")
+	proto.RegisterType((*StreamRequest)(nil), "gokupb.StreamRequest")
+	proto.RegisterType((*UpdateLeaseRequest)(nil), "gokupb.UpdateLeaseRequest")
+	proto.RegisterType((*ExpireLeaseRequest)(nil), "gokupb.ExpireLeaseRequest")
+	proto.RegisterType((*Compare)(nil), "gokupb.Compare")
+	proto.RegisterType((*Op)(nil), "gokupb.Op")
+	proto.RegisterType((*TxnRequest)(nil), "gokupb.TxnRequest")
+	proto.RegisterType((*TxnResponse)(nil), "gokupb.TxnResponse")
+	proto.RegisterType((*GetAtRequest)(nil), "gokupb.GetAtRequest")
+	proto.RegisterType((*ListAtRequest)(nil), "gokupb.ListAtRequest")
+	proto.RegisterType((*HistoryRequest)(nil), "gokupb.HistoryRequest")
+	proto.RegisterType((*Revision)(nil), "gokupb.Revision")
+	proto.RegisterType((*Lease)(nil), "gokupb.Lease")
+	proto.RegisterType((*GrantLeaseRequest)(nil), "gokupb.GrantLeaseRequest")
+	proto.RegisterType((*GrantLeaseResponse)(nil), "gokupb.GrantLeaseResponse")
+	proto.RegisterType((*GetLeaseRequest)(nil), "gokupb.GetLeaseRequest")
+	proto.RegisterType((*ListLeaseKeysRequest)(nil), "gokupb.ListLeaseKeysRequest")
+	proto.RegisterType((*ListLeasesRequest)(nil), "gokupb.ListLeasesRequest")
+	proto.RegisterType((*CreateRequest)(nil), "gokupb.CreateRequest")
+	proto.RegisterType((*CreateResponse)(nil), "gokupb.CreateResponse")
+	proto.RegisterType((*IncrementRequest)(nil), "gokupb.IncrementRequest")
+	proto.RegisterType((*IncrementResponse)(nil), "gokupb.IncrementResponse")
+	proto.RegisterType((*DeletePrefixRequest)(nil), "gokupb.DeletePrefixRequest")
+	proto.RegisterType((*DeletePrefixResponse)(nil), "gokupb.DeletePrefixResponse")
+	proto.RegisterType((*GetManyRequest)(nil), "gokupb.GetManyRequest")
+	proto.RegisterType((*GetManyResponse)(nil), "gokupb.GetManyResponse")
+	proto.RegisterType((*SetManyRequest)(nil), "gokupb.SetManyRequest")
+	proto.RegisterType((*ListSnapshotRequest)(nil), "gokupb.ListSnapshotRequest")
+	proto.RegisterType((*ListSnapshotResponse)(nil), "gokupb.ListSnapshotResponse")
+	proto.RegisterType((*WatchRequest)(nil), "gokupb.WatchRequest")
+	proto.RegisterType((*WatchEvent)(nil), "gokupb.WatchEvent")
+}
+
+func init() { proto.RegisterFile("goku.proto", fileDescriptor_34ec642ad405eef9) }
+
+var fileDescriptor_34ec642ad405eef9 = []byte{
+	// 1554 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0xcd, 0x72, 0xdb, 0x46,
+	0x12, 0x16, 0xf8, 0xcf, 0xa6, 0x44, 0xc9, 0x23, 0xad, 0x45, 0xc3, 0x5a, 0x5b, 0x3b, 0xe5, 0x2a,
+	0xcb, 0x6b, 0x9b, 0xd2, 0x6a, 0x77, 0x6b, 0xd7, 0xaa, 0xdd, 0x83, 0xec, 0xa8, 0x14, 0x97, 0xec,
+	0xd8, 0x01, 0x1d, 0xe7, 0x16, 0x16, 0x44, 0x36, 0x25, 0x44, 0x24, 0x00, 0x03, 0x43, 0x96, 0xe8,
+	0x4b, 0x72, 0xca, 0x03, 0xe4, 0x39, 0x72, 0x48, 0xde, 0x21, 0x97, 0xbc, 0x4b, 0xce, 0x39, 0xa7,
+	0xe6, 0x0f, 0xc0, 0x10, 0xd4, 0x8f, 0x93, 0x54, 0x4e, 0x44, 0xf7, 0x74, 0xcf, 0xf4, 0xff, 0xd7,
+	0x04, 0x38, 0x09, 0xce, 0xc6, 0xed, 0x30, 0x0a, 0x58, 0x40, 0x2a, 0xfc, 0x3b, 0x3c, 0xb6, 0x1f,
+	0x9d, 0x78, 0xec, 0x74, 0x7c, 0xdc, 0xee, 0x05, 0xa3, 0xed, 0xe1, 0xd8, 0x0f, 0xb6, 0x23, 0x1c,
+	0x0c, 0xf1, 0x5c, 0xfd, 0x84, 0xc7, 0xea, 0x43, 0x6a, 0xd9, 0x77, 0x4f, 0x82, 0xe0, 0x64, 0x88,
+	0xdb, 0x82, 0x3a, 0x1e, 0x0f, 0xb6, 0x99, 0x37, 0xc2, 0x98, 0xb9, 0xa3, 0x50, 0x0a, 0xd0, 0x2a,
+	0x94, 0x0f, 0x46, 0x21, 0x9b, 0xd2, 0x1f, 0x2d, 0x28, 0x1c, 0xbd, 0x25, 0x2b, 0x50, 0x3c, 0xc3,
+	0x69, 0xcb, 0xda, 0xb4, 0xb6, 0xea, 0x0e, 0xff, 0x24, 0x6b, 0x50, 0x9e, 0xb8, 0xc3, 0x31, 0xb6,
+	0x0a, 0x9b, 0xd6, 0xd6, 0xa2, 0x23, 0x09, 0xd2, 0x82, 0xea, 0x04, 0xa3, 0xd8, 0x0b, 0xfc, 0x56,
+	0x71, 0xd3, 0xda, 0x2a, 0x3a, 0x9a, 0x24, 0x77, 0xa1, 0xd1, 0x8b, 0xd0, 0x65, 0xd8, 0xef, 0x46,
+	0x38, 0x68, 0x95, 0xc4, 0x29, 0x28, 0x96, 0x83, 0x03, 0x2e, 0x30, 0x0e, 0xfb, 0x89, 0x40, 0x59,
+	0x0a, 0x28, 0x96, 0x12, 0xe8, 0xe3, 0x10, 0xb5, 0x40, 0x45, 0x0a, 0x28, 0x16, 0x17, 0xb8, 0x05,
+	0xb5, 0x21, 0xba, 0x31, 0x76, 0xbd, 0x7e, 0xab, 0x2a, 0x5f, 0x17, 0xf4, 0xf3, 0x3e, 0xbd, 0x03,
+	0x70, 0x88, 0xcc, 0xc1, 0x77, 0x63, 0x8c, 0x59, 0xde, 0x1b, 0xfa, 0x9d, 0x05, 0x8d, 0x17, 0x5e,
+	0x9c, 0x48, 0xdc, 0x84, 0x4a, 0x18, 0xe1, 0xc0, 0x3b, 0x57, 0x42, 0x8a, 0xe2, 0x5e, 0x0f, 0xbd,
+	0x91, 0xc7, 0x84, 0xd7, 0x45, 0x47, 0x12, 0xdc, 0xb2, 0x98, 0xb9, 0x11, 0xeb, 0xba, 0x03, 0x86,
+	0x91, 0xf0, 0xbc, 0xee, 0x80, 0x60, 0xed, 0x73, 0x0e, 0x59, 0x87, 0x2a, 0xfa, 0xfd, 0x2e, 0x7f,
+	0xb4, 0x24, 0xef, 0x43, 0xbf, 0x7f, 0x84, 0x53, 0x1e, 0xaf, 0x08, 0x79, 0x88, 0x50, 0x38, 0x5c,
+	0x73, 0x34, 0x49, 0x6e, 0x43, 0xfd, 0x0c, 0xa7, 0x71, 0x37, 0xf0, 0x87, 0x53, 0xe1, 0x6b, 0xcd,
+	0xa9, 0x71, 0xc6, 0x2b, 0x7f, 0x38, 0xa5, 0x8f, 0x60, 0x51, 0x5a, 0x1b, 0x87, 0x81, 0x1f, 0x23,
+	0xd9, 0x80, 0xe2, 0xd9, 0x24, 0x6e, 0x59, 0x9b, 0xc5, 0xad, 0xc6, 0x2e, 0xb4, 0x65, 0x4d, 0xb4,
+	0x8f, 0xde, 0x3a, 0x9c, 0x4d, 0xbf, 0xb6, 0x60, 0xe9, 0x23, 0x11, 0xa6, 0x0b, 0x03, 0x40, 0xfe,
+	0x06, 0x8b, 0x61, 0x84, 0x93, 0xae, 0xce, 0x9e, 0xf4, 0xaf, 0xc1, 0x79, 0x6f, 0x55, 0x06, 0xb3,
+	0xe1, 0x2d, 0x1a, 0xe1, 0xe5, 0x01, 0x88, 0x90, 0x8d, 0x23, 0xbf, 0xcb, 0x15, 0x84, 0x8f, 0x35,
+	0x07, 0x24, 0xeb, 0x75, 0x84, 0x13, 0xba, 0x03, 0x4d, 0x6d, 0x81, 0x32, 0xf9, 0x0e, 0x94, 0x84,
+	0x2c, 0xb7, 0xc1, 0xb4, 0x59, 0xf0, 0xe9, 0xcf, 0x16, 0x40, 0xe7, 0x92, 0x94, 0x5d, 0x50, 0x80,
+	0x4f, 0x00, 0xf0, 0x3c, 0xf4, 0x22, 0x8c, 0xbb, 0x2e, 0x13, 0x66, 0x36, 0x76, 0xed, 0xb6, 0x2c,
+	0xf7, 0xb6, 0x2e, 0xf7, 0xf6, 0x1b, 0x5d, 0xee, 0x4e, 0x5d, 0x49, 0xef, 0x33, 0xc3, 0xbf, 0x92,
+	0xe9, 0xdf, 0x6c, 0x74, 0xca, 0xf9, 0xe8, 0x24, 0xf5, 0x9d, 0xcd, 0x98, 0xaa, 0x6f, 0x9e, 0x33,
+	0xb2, 0x01, 0x75, 0x0c, 0x4f, 0x71, 0x84, 0x91, 0x3b, 0x14, 0xe5, 0x59, 0x73, 0x52, 0x06, 0x75,
+	0x60, 0xa9, 0xc3, 0x22, 0x74, 0x47, 0x57, 0x55, 0xe0, 0x03, 0x28, 0x46, 0xf8, 0x4e, 0x38, 0xdd,
+	0xd8, 0x5d, 0x6f, 0xeb, 0xfe, 0x6e, 0x1b, 0xda, 0x0e, 0x97, 0xa1, 0x5f, 0x02, 0xf9, 0x4c, 0xb4,
+	0xcf, 0x0b, 0xee, 0x86, 0xbe, 0x38, 0xeb, 0xa6, 0x65, 0xba, 0x69, 0x06, 0xaf, 0xf0, 0x01, 0xc1,
+	0xa3, 0xdb, 0x40, 0x0e, 0x04, 0x71, 0xcd, 0xb7, 0xe8, 0x01, 0x54, 0x9f, 0x05, 0xa3, 0xd0, 0x8d,
+	0x70, 0x4e, 0x6e, 0x09, 0x94, 0xd8, 0x34, 0x94, 0xa9, 0x2d, 0x3b, 0xe2, 0x3b, 0xcd, 0xb7, 0xac,
+	0x3d, 0x49, 0xd0, 0xaf, 0xa0, 0xf0, 0x2a, 0x24, 0xf7, 0xa0, 0x78, 0x82, 0x4c, 0xd5, 0x12, 0xd1,
+	0xb5, 0x94, 0x76, 0xbc, 0xc3, 0x8f, 0xb9, 0x54, 0x8c, 0xda, 0xaf, 0x44, 0xaa, 0x93, 0x91, 0x8a,
+	0x91, 0x91, 0xc7, 0x50, 0x91, 0x33, 0x45, 0x55, 0xcf, 0x5f, 0xb4, 0xa0, 0xd1, 0x42, 0x8e, 0x12,
+	0xa2, 0x53, 0x80, 0x37, 0xe7, 0xbe, 0x76, 0xf8, 0x21, 0xd4, 0x7a, 0xd2, 0x2b, 0xdd, 0x8d, 0xcb,
+	0x5a, 0x5d, 0x79, 0xeb, 0x24, 0x02, 0xbc, 0x05, 0xd8, 0x29, 0xf2, 0x5e, 0x33, 0xda, 0xf6, 0x55,
+	0xe8, 0x08, 0x3e, 0x3f, 0xc7, 0x61, 0xcc, 0xed, 0xc8, 0x9d, 0x73, 0x3e, 0x3d, 0x82, 0x86, 0x78,
+	0x3a, 0x19, 0x02, 0xf5, 0x78, 0xdc, 0xeb, 0x21, 0xf6, 0x51, 0x46, 0xbb, 0xe6, 0xa4, 0x0c, 0x7e,
+	0xd9, 0x09, 0xb2, 0x78, 0xf6, 0x31, 0xde, 0x6f, 0x9c, 0x4f, 0x77, 0x61, 0xf1, 0x10, 0xd9, 0xfe,
+	0x25, 0x0d, 0xb7, 0xc2, 0x2b, 0x6f, 0xa0, 0x26, 0x03, 0xff, 0xa4, 0x4f, 0x60, 0x89, 0x8f, 0xa1,
+	0xfd, 0x2b, 0xc7, 0x66, 0x5e, 0x35, 0x80, 0xe6, 0xc7, 0x5e, 0xcc, 0x82, 0x68, 0x7a, 0x69, 0x87,
+	0xcf, 0x19, 0xb6, 0xb7, 0xa1, 0x2e, 0xc6, 0xac, 0x00, 0x01, 0x59, 0x0b, 0x35, 0xc1, 0xe0, 0x10,
+	0x90, 0x99, 0xa7, 0x25, 0x63, 0x9e, 0xd2, 0x6f, 0x2d, 0xa8, 0x39, 0x38, 0xf1, 0x44, 0xb3, 0x5e,
+	0xaf, 0xe2, 0x94, 0xd5, 0xc5, 0xc4, 0x6a, 0xf2, 0x5f, 0xa8, 0x27, 0x48, 0xd9, 0x2a, 0x5d, 0xdd,
+	0x1f, 0x89, 0x70, 0x5a, 0xbd, 0xe5, 0xcc, 0xb4, 0xa2, 0xdf, 0x58, 0x50, 0x16, 0x0d, 0x43, 0x9a,
+	0x50, 0x48, 0x7a, 0xa4, 0xe0, 0xfd, 0x9e, 0x56, 0xbc, 0x04, 0x83, 0x5b, 0x50, 0x95, 0x62, 0x7d,
+	0x1d, 0x1d, 0x45, 0xd2, 0x4f, 0xe0, 0xc6, 0x61, 0xe4, 0xfa, 0xcc, 0xe8, 0x5e, 0xd3, 0x06, 0xeb,
+	0x03, 0xc7, 0x41, 0xf6, 0x3e, 0x55, 0xa1, 0x97, 0x8c, 0x83, 0x47, 0xb0, 0x7c, 0x88, 0xec, 0xba,
+	0xc3, 0xe3, 0x1f, 0xb0, 0xc6, 0x0b, 0x4f, 0x88, 0x1f, 0xe1, 0x34, 0xbe, 0x86, 0xca, 0xf7, 0x16,
+	0xdc, 0x48, 0x74, 0x12, 0x85, 0x7d, 0x68, 0x6a, 0x17, 0x8f, 0x71, 0x10, 0x44, 0x78, 0x0d, 0x37,
+	0x97, 0x94, 0xc6, 0x53, 0xa1, 0x40, 0xee, 0xc3, 0xb2, 0xe7, 0xf7, 0x86, 0xe3, 0x3e, 0x76, 0x75,
+	0x70, 0x0b, 0x22, 0xb8, 0x4d, 0xc5, 0x96, 0x73, 0xb1, 0xcf, 0x8d, 0x93, 0x85, 0x9b, 0xe2, 0xa7,
+	0xa0, 0x9f, 0xf7, 0xd3, 0x4a, 0x2f, 0x65, 0x2a, 0x9d, 0xfe, 0x60, 0xc1, 0xd2, 0x33, 0x01, 0x20,
+	0xd7, 0x58, 0x4b, 0xfe, 0x34, 0x2c, 0x34, 0x70, 0xac, 0x3c, 0x8b, 0x63, 0x14, 0x9a, 0xda, 0x64,
+	0x95, 0xf4, 0xfc, 0xb2, 0xb5, 0x07, 0x2b, 0xcf, 0xfd, 0x5e, 0x84, 0x23, 0xf4, 0x2f, 0xc7, 0xf7,
+	0x3e, 0x0e, 0x99, 0xab, 0xbb, 0x5f, 0x10, 0xf4, 0x01, 0xdc, 0xc8, 0xe8, 0xaa, 0x27, 0x12, 0xf7,
+	0xad, 0x2c, 0x34, 0xbc, 0x80, 0x55, 0x39, 0xb2, 0x5f, 0x8b, 0x20, 0x5d, 0x15, 0xc3, 0xbf, 0x02,
+	0x1c, 0xbb, 0xac, 0x77, 0xda, 0x8d, 0xbd, 0xf7, 0xa8, 0x1e, 0xad, 0x0b, 0x4e, 0xc7, 0x7b, 0x8f,
+	0x74, 0x07, 0xd6, 0xcc, 0xdb, 0xd4, 0xdb, 0x2d, 0xa8, 0xaa, 0x15, 0x54, 0x57, 0x9c, 0x22, 0xe9,
+	0x3d, 0x68, 0x1e, 0x22, 0x7b, 0xe9, 0xfa, 0xc9, 0x88, 0x23, 0x50, 0xe2, 0x2b, 0x9c, 0x40, 0x86,
+	0xba, 0x23, 0xbe, 0xe9, 0x36, 0x2c, 0x27, 0x52, 0xd7, 0xda, 0xe6, 0xf6, 0xa0, 0xd9, 0x31, 0xaf,
+	0xdd, 0x82, 0xb2, 0xc7, 0x70, 0xa4, 0x35, 0xe6, 0x21, 0x9b, 0x14, 0xa0, 0x8f, 0x61, 0x95, 0xf7,
+	0x40, 0xc7, 0x77, 0xc3, 0xf8, 0x34, 0xb8, 0x6a, 0x6c, 0xd3, 0x2f, 0x60, 0xcd, 0x14, 0x4f, 0x53,
+	0xca, 0x07, 0xa3, 0x95, 0x0e, 0xc6, 0x16, 0x54, 0x43, 0xf4, 0xfb, 0x9e, 0x7f, 0x22, 0x00, 0xa6,
+	0xe8, 0x68, 0x92, 0xd8, 0x50, 0x38, 0x9b, 0xa8, 0xe2, 0xcb, 0xfa, 0x52, 0x38, 0x9b, 0xd0, 0x4f,
+	0x61, 0xf1, 0x73, 0x1e, 0xe0, 0x3f, 0x70, 0xe7, 0xf9, 0xc9, 0x02, 0x10, 0x77, 0x1e, 0x4c, 0xd0,
+	0x67, 0xbf, 0x65, 0xb5, 0x98, 0xf7, 0x5f, 0xa6, 0x64, 0xce, 0xd1, 0x6c, 0x77, 0x94, 0xcd, 0xee,
+	0x50, 0xa1, 0xa9, 0x5c, 0x80, 0x19, 0xd5, 0x0f, 0xc0, 0x8c, 0xdd, 0x5f, 0xea, 0x50, 0x3a, 0x0c,
+	0xce, 0xc6, 0xe4, 0x3e, 0x14, 0x0f, 0x91, 0x91, 0x39, 0x8b, 0x8d, 0x9d, 0x09, 0x29, 0x5d, 0x20,
+	0x0f, 0xa1, 0xc4, 0x13, 0x46, 0x56, 0x35, 0x37, 0xf3, 0x9f, 0xc6, 0x14, 0xdd, 0xb1, 0xc8, 0xdf,
+	0xa1, 0xd8, 0xc9, 0xde, 0x9a, 0x96, 0x8b, 0xbd, 0xa4, 0x79, 0xf2, 0x4f, 0xe0, 0x02, 0x79, 0x02,
+	0x15, 0x59, 0xfd, 0x64, 0xfe, 0x3a, 0x64, 0xdf, 0x9c, 0x65, 0xcb, 0x52, 0xa1, 0x0b, 0xe4, 0x5f,
+	0x50, 0x91, 0x79, 0x4a, 0x55, 0x8d, 0xbc, 0xd9, 0xcb, 0x69, 0x42, 0x45, 0xd2, 0x84, 0x71, 0x7b,
+	0xd0, 0xc8, 0xec, 0xae, 0xc4, 0xd6, 0xaa, 0xf9, 0x85, 0x36, 0x6f, 0xec, 0x1e, 0x34, 0x32, 0xbb,
+	0x68, 0xaa, 0x9b, 0x5f, 0x50, 0xf3, 0xba, 0x3b, 0x50, 0x7c, 0x73, 0xee, 0xa7, 0x41, 0x49, 0x77,
+	0x3b, 0x7b, 0xd5, 0xe0, 0x25, 0xfe, 0x3d, 0x84, 0xb2, 0x58, 0x9c, 0xc8, 0x5a, 0x26, 0x3d, 0xfb,
+	0x17, 0x24, 0x68, 0x1b, 0x2a, 0x72, 0x63, 0x4a, 0x83, 0x61, 0x6c, 0x50, 0xb9, 0x24, 0xfd, 0x07,
+	0xaa, 0x6a, 0x4f, 0x22, 0x49, 0x88, 0xcd, 0xc5, 0xc9, 0x5e, 0xd1, 0x7c, 0xbd, 0xde, 0x08, 0xc5,
+	0x03, 0x80, 0x14, 0x81, 0xc9, 0xad, 0xc4, 0xb6, 0x59, 0x94, 0xb7, 0xed, 0x79, 0x47, 0x99, 0xec,
+	0xd5, 0x34, 0x2e, 0x93, 0xf5, 0x8c, 0x83, 0xf3, 0xa3, 0x28, 0xb8, 0x74, 0x81, 0xfc, 0x5f, 0x2e,
+	0x86, 0x09, 0x3e, 0x93, 0x8d, 0xac, 0xb7, 0xb3, 0xb0, 0x9d, 0x73, 0x7a, 0x0f, 0x20, 0x91, 0x8b,
+	0x53, 0xdb, 0x73, 0xf0, 0x9d, 0x7b, 0x78, 0xc7, 0xe2, 0x95, 0x2a, 0x01, 0x28, 0x8d, 0xb0, 0x81,
+	0xa1, 0xf6, 0xcd, 0x59, 0x76, 0xe2, 0xeb, 0x53, 0xa8, 0x27, 0xd8, 0x42, 0x5a, 0x5a, 0x6c, 0x16,
+	0xaa, 0xec, 0x5b, 0x73, 0x4e, 0x92, 0x3b, 0x8e, 0x60, 0x31, 0x0b, 0x13, 0xe4, 0xb6, 0xd9, 0x17,
+	0x06, 0x14, 0xd9, 0x1b, 0xf3, 0x0f, 0x93, 0xcb, 0xfe, 0x07, 0x55, 0x85, 0x0d, 0x69, 0xf2, 0x4d,
+	0x48, 0xb1, 0xd7, 0x73, 0xfc, 0x44, 0x7b, 0x17, 0xaa, 0x9d, 0x59, 0x6d, 0x13, 0x39, 0xf2, 0xe5,
+	0xff, 0x12, 0x16, 0xb3, 0x13, 0x3f, 0x35, 0x7f, 0x0e, 0x6c, 0xd8, 0x1b, 0xf3, 0x0f, 0xb5, 0x01,
+	0x3b, 0x16, 0xf9, 0x37, 0x94, 0xc5, 0x30, 0x4e, 0x7b, 0x23, 0x3b, 0xef, 0x6d, 0x62, 0x70, 0x93,
+	0xe6, 0x3f, 0xae, 0x88, 0xb9, 0xf8, 0xcf, 0x5f, 0x07, 0x00, 0x58, 0x77, 0x8a, 0xb9, 0xe9, 0x12,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// GokuClient is the client API for Goku service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type GokuClient interface {
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*KV, error)
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (Goku_ListClient, error)
+	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*Empty, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	Stream(ctx context.Context, in *StreamRequest, opts ...grpc.CallOption) (Goku_StreamClient, error)
+	UpdateLease(ctx context.Context, in *UpdateLeaseRequest, opts ...grpc.CallOption) (*Empty, error)
+	ExpireLease(ctx context.Context, in *ExpireLeaseRequest, opts ...grpc.CallOption) (*Empty, error)
+	Txn(ctx context.Context, in *TxnRequest, opts ...grpc.CallOption) (*TxnResponse, error)
+	GetAt(ctx context.Context, in *GetAtRequest, opts ...grpc.CallOption) (*KV, error)
+	ListAt(ctx context.Context, in *ListAtRequest, opts ...grpc.CallOption) (Goku_ListAtClient, error)
+	History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (Goku_HistoryClient, error)
+	GrantLease(ctx context.Context, in *GrantLeaseRequest, opts ...grpc.CallOption) (*GrantLeaseResponse, error)
+	GetLease(ctx context.Context, in *GetLeaseRequest, opts ...grpc.CallOption) (*Lease, error)
+	ListLeaseKeys(ctx context.Context, in *ListLeaseKeysRequest, opts ...grpc.CallOption) (Goku_ListLeaseKeysClient, error)
+	ListLeases(ctx context.Context, in *ListLeasesRequest, opts ...grpc.CallOption) (Goku_ListLeasesClient, error)
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	Increment(ctx context.Context, in *IncrementRequest, opts ...grpc.CallOption) (*IncrementResponse, error)
+	DeletePrefix(ctx context.Context, in *DeletePrefixRequest, opts ...grpc.CallOption) (*DeletePrefixResponse, error)
+	GetMany(ctx context.Context, in *GetManyRequest, opts ...grpc.CallOption) (*GetManyResponse, error)
+	SetMany(ctx context.Context, in *SetManyRequest, opts ...grpc.CallOption) (*Empty, error)
+	ListSnapshot(ctx context.Context, in *ListSnapshotRequest, opts ...grpc.CallOption) (Goku_ListSnapshotClient, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Goku_WatchClient, error)
+}
+
+type gokuClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewGokuClient(cc *grpc.ClientConn) GokuClient {
+	return &gokuClient{cc}
+}
+
+func (c *gokuClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*KV, error) {
+	out := new(KV)
+	err := c.cc.Invoke(ctx, "/gokupb.Goku/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokuClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (Goku_ListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Goku_serviceDesc.Streams[0], "/gokupb.Goku/List", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gokuListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Goku_ListClient interface {
+	Recv() (*KV, error)
+	grpc.ClientStream
+}
+
+type gokuListClient struct {
+	grpc.ClientStream
+}
+
+func (x *gokuListClient) Recv() (*KV, error) {
+	m := new(KV)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gokuClient) Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/gokupb.Goku/Set", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokuClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, "/gokupb.Goku/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokuClient) Stream(ctx context.Context, in *StreamRequest, opts ...grpc.CallOption) (Goku_StreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Goku_serviceDesc.Streams[1], "/gokupb.Goku/Stream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gokuStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Goku_StreamClient interface {
+	Recv() (*reflexpb.Event, error)
+	grpc.ClientStream
+}
+
+type gokuStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *gokuStreamClient) Recv() (*reflexpb.Event, error) {
+	m := new(reflexpb.Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gokuClient) UpdateLease(ctx context.Context, in *UpdateLeaseRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/gokupb.Goku/UpdateLease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokuClient) ExpireLease(ctx context.Context, in *ExpireLeaseRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/gokupb.Goku/ExpireLease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokuClient) Txn(ctx context.Context, in *TxnRequest, opts ...grpc.CallOption) (*TxnResponse, error) {
+	out := new(TxnResponse)
+	err := c.cc.Invoke(ctx, "/gokupb.Goku/Txn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokuClient) GetAt(ctx context.Context, in *GetAtRequest, opts ...grpc.CallOption) (*KV, error) {
+	out := new(KV)
+	err := c.cc.Invoke(ctx, "/gokupb.Goku/GetAt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokuClient) ListAt(ctx context.Context, in *ListAtRequest, opts ...grpc.CallOption) (Goku_ListAtClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Goku_serviceDesc.Streams[2], "/gokupb.Goku/ListAt", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gokuListAtClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Goku_ListAtClient interface {
+	Recv() (*KV, error)
+	grpc.ClientStream
+}
+
+type gokuListAtClient struct {
+	grpc.ClientStream
+}
+
+func (x *gokuListAtClient) Recv() (*KV, error) {
+	m := new(KV)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gokuClient) History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (Goku_HistoryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Goku_serviceDesc.Streams[3], "/gokupb.Goku/History", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gokuHistoryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Goku_HistoryClient interface {
+	Recv() (*Revision, error)
+	grpc.ClientStream
+}
+
+type gokuHistoryClient struct {
+	grpc.ClientStream
+}
+
+func (x *gokuHistoryClient) Recv() (*Revision, error) {
+	m := new(Revision)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gokuClient) GrantLease(ctx context.Context, in *GrantLeaseRequest, opts ...grpc.CallOption) (*GrantLeaseResponse, error) {
+	out := new(GrantLeaseResponse)
+	err := c.cc.Invoke(ctx, "/gokupb.Goku/GrantLease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokuClient) GetLease(ctx context.Context, in *GetLeaseRequest, opts ...grpc.CallOption) (*Lease, error) {
+	out := new(Lease)
+	err := c.cc.Invoke(ctx, "/gokupb.Goku/GetLease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokuClient) ListLeaseKeys(ctx context.Context, in *ListLeaseKeysRequest, opts ...grpc.CallOption) (Goku_ListLeaseKeysClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Goku_serviceDesc.Streams[4], "/gokupb.Goku/ListLeaseKeys", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gokuListLeaseKeysClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Goku_ListLeaseKeysClient interface {
+	Recv() (*KV, error)
+	grpc.ClientStream
+}
+
+type gokuListLeaseKeysClient struct {
+	grpc.ClientStream
+}
+
+func (x *gokuListLeaseKeysClient) Recv() (*KV, error) {
+	m := new(KV)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gokuClient) ListLeases(ctx context.Context, in *ListLeasesRequest, opts ...grpc.CallOption) (Goku_ListLeasesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Goku_serviceDesc.Streams[5], "/gokupb.Goku/ListLeases", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gokuListLeasesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Goku_ListLeasesClient interface {
+	Recv() (*Lease, error)
+	grpc.ClientStream
+}
+
+type gokuListLeasesClient struct {
+	grpc.ClientStream
+}
+
+func (x *gokuListLeasesClient) Recv() (*Lease, error) {
+	m := new(Lease)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gokuClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, "/gokupb.Goku/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokuClient) Increment(ctx context.Context, in *IncrementRequest, opts ...grpc.CallOption) (*IncrementResponse, error) {
+	out := new(IncrementResponse)
+	err := c.cc.Invoke(ctx, "/gokupb.Goku/Increment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokuClient) DeletePrefix(ctx context.Context, in *DeletePrefixRequest, opts ...grpc.CallOption) (*DeletePrefixResponse, error) {
+	out := new(DeletePrefixResponse)
+	err := c.cc.Invoke(ctx, "/gokupb.Goku/DeletePrefix", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokuClient) GetMany(ctx context.Context, in *GetManyRequest, opts ...grpc.CallOption) (*GetManyResponse, error) {
+	out := new(GetManyResponse)
+	err := c.cc.Invoke(ctx, "/gokupb.Goku/GetMany", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokuClient) SetMany(ctx context.Context, in *SetManyRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/gokupb.Goku/SetMany", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gokuClient) ListSnapshot(ctx context.Context, in *ListSnapshotRequest, opts ...grpc.CallOption) (Goku_ListSnapshotClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Goku_serviceDesc.Streams[6], "/gokupb.Goku/ListSnapshot", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gokuListSnapshotClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Goku_ListSnapshotClient interface {
+	Recv() (*ListSnapshotResponse, error)
+	grpc.ClientStream
+}
+
+type gokuListSnapshotClient struct {
+	grpc.ClientStream
+}
+
+func (x *gokuListSnapshotClient) Recv() (*ListSnapshotResponse, error) {
+	m := new(ListSnapshotResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gokuClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Goku_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Goku_serviceDesc.Streams[7], "/gokupb.Goku/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gokuWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Goku_WatchClient interface {
+	Recv() (*WatchEvent, error)
+	grpc.ClientStream
+}
+
+type gokuWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *gokuWatchClient) Recv() (*WatchEvent, error) {
+	m := new(WatchEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// GokuServer is the server API for Goku service.
+type GokuServer interface {
+	Get(context.Context, *GetRequest) (*KV, error)
+	List(*ListRequest, Goku_ListServer) error
+	Set(context.Context, *SetRequest) (*Empty, error)
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	Stream(*StreamRequest, Goku_StreamServer) error
+	UpdateLease(context.Context, *UpdateLeaseRequest) (*Empty, error)
+	ExpireLease(context.Context, *ExpireLeaseRequest) (*Empty, error)
+	Txn(context.Context, *TxnRequest) (*TxnResponse, error)
+	GetAt(context.Context, *GetAtRequest) (*KV, error)
+	ListAt(*ListAtRequest, Goku_ListAtServer) error
+	History(*HistoryRequest, Goku_HistoryServer) error
+	GrantLease(context.Context, *GrantLeaseRequest) (*GrantLeaseResponse, error)
+	GetLease(context.Context, *GetLeaseRequest) (*Lease, error)
+	ListLeaseKeys(*ListLeaseKeysRequest, Goku_ListLeaseKeysServer) error
+	ListLeases(*ListLeasesRequest, Goku_ListLeasesServer) error
+	Create(context.Context, *CreateRequest) (*CreateResponse, error)
+	Increment(context.Context, *IncrementRequest) (*IncrementResponse, error)
+	DeletePrefix(context.Context, *DeletePrefixRequest) (*DeletePrefixResponse, error)
+	GetMany(context.Context, *GetManyRequest) (*GetManyResponse, error)
+	SetMany(context.Context, *SetManyRequest) (*Empty, error)
+	ListSnapshot(*ListSnapshotRequest, Goku_ListSnapshotServer) error
+	Watch(*WatchRequest, Goku_WatchServer) error
+}
+
+// UnimplementedGokuServer can be embedded to have forward compatible implementations.
+type UnimplementedGokuServer struct {
+}
+
+func (*UnimplementedGokuServer) Get(ctx context.Context, req *GetRequest) (*KV, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (*UnimplementedGokuServer) List(req *ListRequest, srv Goku_ListServer) error {
+	return status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (*UnimplementedGokuServer) Set(ctx context.Context, req *SetRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
+}
+func (*UnimplementedGokuServer) Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (*UnimplementedGokuServer) Stream(req *StreamRequest, srv Goku_StreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method Stream not implemented")
+}
+func (*UnimplementedGokuServer) UpdateLease(ctx context.Context, req *UpdateLeaseRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLease not implemented")
+}
+func (*UnimplementedGokuServer) ExpireLease(ctx context.Context, req *ExpireLeaseRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExpireLease not implemented")
 }
+func (*UnimplementedGokuServer) Txn(ctx context.Context, req *TxnRequest) (*TxnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Txn not implemented")
+}
+func (*UnimplementedGokuServer) GetAt(ctx context.Context, req *GetAtRequest) (*KV, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAt not implemented")
+}
+func (*UnimplementedGokuServer) ListAt(req *ListAtRequest, srv Goku_ListAtServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListAt not implemented")
+}
+func (*UnimplementedGokuServer) History(req *HistoryRequest, srv Goku_HistoryServer) error {
+	return status.Errorf(codes.Unimplemented, "method History not implemented")
+}
+func (*UnimplementedGokuServer) GrantLease(ctx context.Context, req *GrantLeaseRequest) (*GrantLeaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantLease not implemented")
+}
+func (*UnimplementedGokuServer) GetLease(ctx context.Context, req *GetLeaseRequest) (*Lease, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLease not implemented")
+}
+func (*UnimplementedGokuServer) ListLeaseKeys(req *ListLeaseKeysRequest, srv Goku_ListLeaseKeysServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListLeaseKeys not implemented")
+}
+func (*UnimplementedGokuServer) ListLeases(req *ListLeasesRequest, srv Goku_ListLeasesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListLeases not implemented")
+}
+func (*UnimplementedGokuServer) Create(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (*UnimplementedGokuServer) Increment(ctx context.Context, req *IncrementRequest) (*IncrementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Increment not implemented")
+}
+func (*UnimplementedGokuServer) DeletePrefix(ctx context.Context, req *DeletePrefixRequest) (*DeletePrefixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePrefix not implemented")
+}
+func (*UnimplementedGokuServer) GetMany(ctx context.Context, req *GetManyRequest) (*GetManyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMany not implemented")
+}
+func (*UnimplementedGokuServer) SetMany(ctx context.Context, req *SetManyRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMany not implemented")
+}
+func (*UnimplementedGokuServer) ListSnapshot(req *ListSnapshotRequest, srv Goku_ListSnapshotServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListSnapshot not implemented")
+}
+func (*UnimplementedGokuServer) Watch(req *WatchRequest, srv Goku_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
 
 func RegisterGokuServer(s *grpc.Server, srv GokuServer) {
 	s.RegisterService(&_Goku_serviceDesc, srv)
@@ -878,6 +2785,294 @@ func _Goku_ExpireLease_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Goku_Txn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokuServer).Txn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gokupb.Goku/Txn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokuServer).Txn(ctx, req.(*TxnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goku_GetAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokuServer).GetAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gokupb.Goku/GetAt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokuServer).GetAt(ctx, req.(*GetAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goku_ListAt_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListAtRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GokuServer).ListAt(m, &gokuListAtServer{stream})
+}
+
+type Goku_ListAtServer interface {
+	Send(*KV) error
+	grpc.ServerStream
+}
+
+type gokuListAtServer struct {
+	grpc.ServerStream
+}
+
+func (x *gokuListAtServer) Send(m *KV) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Goku_History_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(HistoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GokuServer).History(m, &gokuHistoryServer{stream})
+}
+
+type Goku_HistoryServer interface {
+	Send(*Revision) error
+	grpc.ServerStream
+}
+
+type gokuHistoryServer struct {
+	grpc.ServerStream
+}
+
+func (x *gokuHistoryServer) Send(m *Revision) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Goku_GrantLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokuServer).GrantLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gokupb.Goku/GrantLease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokuServer).GrantLease(ctx, req.(*GrantLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goku_GetLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokuServer).GetLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gokupb.Goku/GetLease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokuServer).GetLease(ctx, req.(*GetLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goku_ListLeaseKeys_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListLeaseKeysRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GokuServer).ListLeaseKeys(m, &gokuListLeaseKeysServer{stream})
+}
+
+type Goku_ListLeaseKeysServer interface {
+	Send(*KV) error
+	grpc.ServerStream
+}
+
+type gokuListLeaseKeysServer struct {
+	grpc.ServerStream
+}
+
+func (x *gokuListLeaseKeysServer) Send(m *KV) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Goku_ListLeases_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListLeasesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GokuServer).ListLeases(m, &gokuListLeasesServer{stream})
+}
+
+type Goku_ListLeasesServer interface {
+	Send(*Lease) error
+	grpc.ServerStream
+}
+
+type gokuListLeasesServer struct {
+	grpc.ServerStream
+}
+
+func (x *gokuListLeasesServer) Send(m *Lease) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Goku_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokuServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gokupb.Goku/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokuServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goku_Increment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncrementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokuServer).Increment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gokupb.Goku/Increment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokuServer).Increment(ctx, req.(*IncrementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goku_DeletePrefix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePrefixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokuServer).DeletePrefix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gokupb.Goku/DeletePrefix",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokuServer).DeletePrefix(ctx, req.(*DeletePrefixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goku_GetMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetManyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokuServer).GetMany(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gokupb.Goku/GetMany",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokuServer).GetMany(ctx, req.(*GetManyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goku_SetMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetManyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GokuServer).SetMany(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gokupb.Goku/SetMany",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GokuServer).SetMany(ctx, req.(*SetManyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Goku_ListSnapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListSnapshotRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GokuServer).ListSnapshot(m, &gokuListSnapshotServer{stream})
+}
+
+type Goku_ListSnapshotServer interface {
+	Send(*ListSnapshotResponse) error
+	grpc.ServerStream
+}
+
+type gokuListSnapshotServer struct {
+	grpc.ServerStream
+}
+
+func (x *gokuListSnapshotServer) Send(m *ListSnapshotResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Goku_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GokuServer).Watch(m, &gokuWatchServer{stream})
+}
+
+type Goku_WatchServer interface {
+	Send(*WatchEvent) error
+	grpc.ServerStream
+}
+
+type gokuWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *gokuWatchServer) Send(m *WatchEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Goku_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "gokupb.Goku",
 	HandlerType: (*GokuServer)(nil),
@@ -902,6 +3097,42 @@ var _Goku_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ExpireLease",
 			Handler:    _Goku_ExpireLease_Handler,
 		},
+		{
+			MethodName: "Txn",
+			Handler:    _Goku_Txn_Handler,
+		},
+		{
+			MethodName: "GetAt",
+			Handler:    _Goku_GetAt_Handler,
+		},
+		{
+			MethodName: "GrantLease",
+			Handler:    _Goku_GrantLease_Handler,
+		},
+		{
+			MethodName: "GetLease",
+			Handler:    _Goku_GetLease_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _Goku_Create_Handler,
+		},
+		{
+			MethodName: "Increment",
+			Handler:    _Goku_Increment_Handler,
+		},
+		{
+			MethodName: "DeletePrefix",
+			Handler:    _Goku_DeletePrefix_Handler,
+		},
+		{
+			MethodName: "GetMany",
+			Handler:    _Goku_GetMany_Handler,
+		},
+		{
+			MethodName: "SetMany",
+			Handler:    _Goku_SetMany_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -914,6 +3145,36 @@ var _Goku_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Goku_Stream_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ListAt",
+			Handler:       _Goku_ListAt_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "History",
+			Handler:       _Goku_History_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListLeaseKeys",
+			Handler:       _Goku_ListLeaseKeys_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListLeases",
+			Handler:       _Goku_ListLeases_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListSnapshot",
+			Handler:       _Goku_ListSnapshot_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Watch",
+			Handler:       _Goku_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "goku.proto",
 }
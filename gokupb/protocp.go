@@ -1,6 +1,11 @@
 package gokupb
 
-import "github.com/corverroos/goku"
+import (
+	"github.com/corverroos/goku"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+)
 
 func FromProto(in *KV) goku.KV {
 	return goku.KV{
@@ -25,3 +30,190 @@ func ToProto(in goku.KV) *KV {
 		LeaseId:    in.LeaseID,
 	}
 }
+
+func CompareFromProto(in *Compare) goku.Compare {
+	return goku.Compare{
+		Key:   in.Key,
+		Type:  goku.CompareType(in.Type),
+		Value: in.Value,
+	}
+}
+
+func CompareToProto(in goku.Compare) *Compare {
+	return &Compare{
+		Key:   in.Key,
+		Type:  int32(in.Type),
+		Value: in.Value,
+	}
+}
+
+func OpFromProto(in *Op) (goku.Op, error) {
+	switch {
+	case in.Get != nil:
+		return goku.OpGet(in.Get.Key), nil
+	case in.Delete != nil:
+		return goku.Op{
+			Type: goku.OpTypeDelete,
+			Key:  in.Delete.Key,
+			DeleteOptions: goku.DeleteOptions{
+				PrevVersion: in.Delete.PrevVersion,
+				LeaseID:     in.Delete.LeaseId,
+			},
+		}, nil
+	case in.Set != nil:
+		expiresAt, err := ptypes.Timestamp(in.Set.ExpiresAt)
+		if err != nil {
+			return goku.Op{}, err
+		}
+
+		return goku.Op{
+			Type:  goku.OpTypeSet,
+			Key:   in.Set.Key,
+			Value: in.Set.Value,
+			SetOptions: goku.SetOptions{
+				ExpiresAt:   expiresAt,
+				LeaseID:     in.Set.LeaseId,
+				PrevVersion: in.Set.PrevVersion,
+				CreateOnly:  in.Set.CreateOnly,
+				Ephemeral:   in.Set.Ephemeral,
+			},
+		}, nil
+	default:
+		return goku.Op{}, errors.New("empty op")
+	}
+}
+
+func OpToProto(in goku.Op) (*Op, error) {
+	switch in.Type {
+	case goku.OpTypeGet:
+		return &Op{Get: &GetRequest{Key: in.Key}}, nil
+	case goku.OpTypeDelete:
+		return &Op{Delete: &DeleteRequest{
+			Key:         in.Key,
+			PrevVersion: in.DeleteOptions.PrevVersion,
+			LeaseId:     in.DeleteOptions.LeaseID,
+		}}, nil
+	case goku.OpTypeSet:
+		req, err := SetItemToProto(goku.SetItem{
+			Key:        in.Key,
+			Value:      in.Value,
+			SetOptions: in.SetOptions,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &Op{Set: req}, nil
+	default:
+		return nil, errors.New("invalid op type", j.KV("type", in.Type))
+	}
+}
+
+func SetItemToProto(in goku.SetItem) (*SetRequest, error) {
+	expiresAt, err := ptypes.TimestampProto(in.SetOptions.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetRequest{
+		Key:         in.Key,
+		Value:       in.Value,
+		ExpiresAt:   expiresAt,
+		LeaseId:     in.SetOptions.LeaseID,
+		PrevVersion: in.SetOptions.PrevVersion,
+		CreateOnly:  in.SetOptions.CreateOnly,
+		Ephemeral:   in.SetOptions.Ephemeral,
+	}, nil
+}
+
+func RevisionFromProto(in *Revision) (goku.Revision, error) {
+	ts, err := ptypes.Timestamp(in.Timestamp)
+	if err != nil {
+		return goku.Revision{}, err
+	}
+
+	return goku.Revision{
+		Key:       in.Key,
+		Type:      goku.EventType(in.Type),
+		Ref:       in.Ref,
+		Timestamp: ts,
+		Value:     in.Value,
+	}, nil
+}
+
+func RevisionToProto(in goku.Revision) (*Revision, error) {
+	ts, err := ptypes.TimestampProto(in.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Revision{
+		Key:       in.Key,
+		Type:      int32(in.Type),
+		Ref:       in.Ref,
+		Timestamp: ts,
+		Value:     in.Value,
+	}, nil
+}
+
+func WatchEventFromProto(in *WatchEvent) (goku.WatchEvent, error) {
+	ts, err := ptypes.Timestamp(in.Timestamp)
+	if err != nil {
+		return goku.WatchEvent{}, err
+	}
+
+	return goku.WatchEvent{
+		Key:       in.Key,
+		Type:      goku.EventType(in.Type),
+		Value:     in.Value,
+		Version:   in.Version,
+		LeaseID:   in.LeaseId,
+		Ref:       in.Ref,
+		Timestamp: ts,
+	}, nil
+}
+
+func WatchEventToProto(in goku.WatchEvent) (*WatchEvent, error) {
+	ts, err := ptypes.TimestampProto(in.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WatchEvent{
+		Key:       in.Key,
+		Type:      int32(in.Type),
+		Value:     in.Value,
+		Version:   in.Version,
+		LeaseId:   in.LeaseID,
+		Ref:       in.Ref,
+		Timestamp: ts,
+	}, nil
+}
+
+func LeaseFromProto(in *Lease) (goku.Lease, error) {
+	expiresAt, err := ptypes.Timestamp(in.ExpiresAt)
+	if err != nil {
+		return goku.Lease{}, err
+	}
+
+	return goku.Lease{
+		ID:        in.Id,
+		ExpiresAt: expiresAt,
+		Version:   in.Version,
+		Expired:   in.Expired,
+	}, nil
+}
+
+func LeaseToProto(in goku.Lease) (*Lease, error) {
+	expiresAt, err := ptypes.TimestampProto(in.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Lease{
+		Id:        in.ID,
+		ExpiresAt: expiresAt,
+		Version:   in.Version,
+		Expired:   in.Expired,
+	}, nil
+}
@@ -9,6 +9,7 @@ type SetOptions struct {
 	LeaseID     int64
 	PrevVersion int64
 	CreateOnly  bool
+	Ephemeral   bool
 }
 
 func WithExpiresAt(t time.Time) SetOption {
@@ -17,6 +18,8 @@ func WithExpiresAt(t time.Time) SetOption {
 	}
 }
 
+// WithLeaseID returns an option to associate the key-value with the existing lease. The expiry
+// of the lease is left as is, unless WithExpiresAt is also provided.
 func WithLeaseID(id int64) SetOption {
 	return func(o *SetOptions) {
 		o.LeaseID = id
@@ -34,3 +37,192 @@ func WithCreateOnly() SetOption {
 		o.CreateOnly = true
 	}
 }
+
+// WithEphemeral returns an option to bind the key-value to the client's grpc connection. The key-value
+// is expired when the connection to the server is closed. It cannot be combined with
+// WithLeaseID or WithExpiresAt.
+func WithEphemeral() SetOption {
+	return func(o *SetOptions) {
+		o.Ephemeral = true
+	}
+}
+
+type DeleteOption func(*DeleteOptions)
+
+type DeleteOptions struct {
+	PrevVersion int64
+	LeaseID     int64
+	Prev        *KV
+}
+
+// WithDeletePrevVersion returns an option to only delete the key-value if its version
+// equals the provided version, otherwise ErrConditional is returned.
+func WithDeletePrevVersion(prevVersion int64) DeleteOption {
+	return func(o *DeleteOptions) {
+		o.PrevVersion = prevVersion
+	}
+}
+
+// WithDeleteIfLease returns an option to only delete the key-value if it is associated with
+// the provided lease, otherwise ErrConditional is returned.
+func WithDeleteIfLease(leaseID int64) DeleteOption {
+	return func(o *DeleteOptions) {
+		o.LeaseID = leaseID
+	}
+}
+
+// WithDeleteReturnPrev returns an option to populate prev with the key-value as it was before it was deleted.
+func WithDeleteReturnPrev(prev *KV) DeleteOption {
+	return func(o *DeleteOptions) {
+		o.Prev = prev
+	}
+}
+
+type DeletePrefixOption func(*DeletePrefixOptions)
+
+type DeletePrefixOptions struct {
+	BatchSize int64
+}
+
+// WithDeleteBatchSize returns an option to set the maximum number of key-values
+// deleted per transaction by DeletePrefix. It defaults to 1000.
+func WithDeleteBatchSize(n int64) DeletePrefixOption {
+	return func(o *DeletePrefixOptions) {
+		o.BatchSize = n
+	}
+}
+
+type ListOption func(*ListOptions)
+
+type ListOptions struct {
+	Limit      int64
+	StartAfter string
+	EndKey     string
+	Reverse    bool
+	KeysOnly   bool
+	Cursor     *string
+}
+
+// SetCursor sets the continuation cursor (if requested via WithCursor) of the listed key-values.
+func (o ListOptions) SetCursor(kvs []KV) {
+	if o.Cursor == nil {
+		return
+	}
+
+	*o.Cursor = ""
+	if o.Limit > 0 && int64(len(kvs)) >= o.Limit {
+		*o.Cursor = kvs[len(kvs)-1].Key
+	}
+}
+
+// WithLimit returns an option to limit the number of key-values returned by List. Use
+// WithCursor to get the continuation cursor to list the next page.
+func WithLimit(limit int64) ListOption {
+	return func(o *ListOptions) {
+		o.Limit = limit
+	}
+}
+
+// WithStartAfter returns an option to only list keys after (exclusive) the provided key (or before if reversed).
+func WithStartAfter(key string) ListOption {
+	return func(o *ListOptions) {
+		o.StartAfter = key
+	}
+}
+
+// WithEndKey returns an option to only list keys before (exclusive) the provided key (or after if reversed).
+func WithEndKey(key string) ListOption {
+	return func(o *ListOptions) {
+		o.EndKey = key
+	}
+}
+
+// WithReverse returns an option to list key-values in descending key order.
+func WithReverse() ListOption {
+	return func(o *ListOptions) {
+		o.Reverse = true
+	}
+}
+
+// WithKeysOnly returns an option to list key-values without values.
+func WithKeysOnly() ListOption {
+	return func(o *ListOptions) {
+		o.KeysOnly = true
+	}
+}
+
+// WithCursor returns an option to populate the continuation cursor of a List with a limit. It is
+// set to the key of the last key-value if the limit was reached, list the next page by providing it
+// via WithStartAfter. Otherwise it is set to empty since there are no more key-values.
+func WithCursor(cursor *string) ListOption {
+	return func(o *ListOptions) {
+		o.Cursor = cursor
+	}
+}
+
+type HistoryOption func(*HistoryOptions)
+
+type HistoryOptions struct {
+	Limit    int64
+	AfterRef int64
+	Reverse  bool
+}
+
+// WithHistoryLimit returns an option to limit the number of revisions returned by History. Use
+// the ref of the last revision returned as continuation cursor via WithHistoryAfter to get the next page.
+func WithHistoryLimit(limit int64) HistoryOption {
+	return func(o *HistoryOptions) {
+		o.Limit = limit
+	}
+}
+
+// WithHistoryAfter returns an option to only return revisions after (exclusive) the provided ref (or before if reversed).
+func WithHistoryAfter(ref int64) HistoryOption {
+	return func(o *HistoryOptions) {
+		o.AfterRef = ref
+	}
+}
+
+// WithHistoryReverse returns an option to return revisions in descending ref order; ie. latest first.
+func WithHistoryReverse() HistoryOption {
+	return func(o *HistoryOptions) {
+		o.Reverse = true
+	}
+}
+
+type ListLeasesOption func(*ListLeasesOptions)
+
+type ListLeasesOptions struct {
+	ExpiresBefore  time.Time
+	IncludeExpired bool
+	AfterID        int64
+	Limit          int64
+}
+
+// WithExpiresBefore returns an option to only list leases that expire before the provided time.
+func WithExpiresBefore(t time.Time) ListLeasesOption {
+	return func(o *ListLeasesOptions) {
+		o.ExpiresBefore = t
+	}
+}
+
+// WithIncludeExpired returns an option to also list expired leases.
+func WithIncludeExpired() ListLeasesOption {
+	return func(o *ListLeasesOptions) {
+		o.IncludeExpired = true
+	}
+}
+
+// WithLeasesAfter returns an option to only list leases with ids after (exclusive) the provided id.
+func WithLeasesAfter(id int64) ListLeasesOption {
+	return func(o *ListLeasesOptions) {
+		o.AfterID = id
+	}
+}
+
+// WithLeasesLimit returns an option to limit the number of leases returned by ListLeases.
+func WithLeasesLimit(limit int64) ListLeasesOption {
+	return func(o *ListLeasesOptions) {
+		o.Limit = limit
+	}
+}
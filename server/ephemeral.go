@@ -0,0 +1,219 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/luno/jettison/errors"
+	"github.com/luno/jettison/j"
+	"github.com/luno/jettison/log"
+	"google.golang.org/grpc/stats"
+)
+
+const defaultEphemeralTTL = time.Second * 30
+
+type connKey struct{}
+
+// conn tracks the ephemeral lease of a grpc connection. The lease is only
+// granted on the first ephemeral set of the connection.
+type conn struct {
+	mu        sync.Mutex
+	leaseID   int64
+	expiresAt time.Time
+	closed    bool
+}
+
+// WithEphemeralTTL returns an option to set the expiry of ephemeral leases. Ephemeral leases
+// are kept alive by the server while the connection is open and are expired when the connection
+// is closed. The ttl only applies if the server itself goes away. Ttls too short to be kept
+// alive (less than 3ns) are ignored and the default of 30s is used.
+func WithEphemeralTTL(ttl time.Duration) Option {
+	return func(s *Server) {
+		if ttl/3 <= 0 {
+			// Ephemeral leases are kept alive every ttl/3.
+			return
+		}
+		s.ephemeralTTL = ttl
+	}
+}
+
+// StatsHandler returns a grpc stats handler that tracks client connections. It must be
+// registered via grpc.StatsHandler for ephemeral key-values to be supported.
+func (s *Server) StatsHandler() stats.Handler {
+	return statsHandler{s: s}
+}
+
+type statsHandler struct {
+	s *Server
+}
+
+func (h statsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return context.WithValue(ctx, connKey{}, new(conn))
+}
+
+func (h statsHandler) HandleConn(ctx context.Context, cs stats.ConnStats) {
+	c, ok := ctx.Value(connKey{}).(*conn)
+	if !ok {
+		return
+	}
+
+	switch cs.(type) {
+	case *stats.ConnBegin:
+		h.s.connMu.Lock()
+		h.s.conns[c] = true
+		h.s.connMu.Unlock()
+	case *stats.ConnEnd:
+		h.s.connMu.Lock()
+		delete(h.s.conns, c)
+		h.s.connMu.Unlock()
+
+		h.s.closeConn(context.Background(), c)
+	}
+}
+
+func (h statsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (h statsHandler) HandleRPC(context.Context, stats.RPCStats) {}
+
+// resolveEphemeral returns the set options with the lease of the connection if the options
+// are ephemeral, otherwise it returns the options as is.
+func (s *Server) resolveEphemeral(ctx context.Context, o goku.SetOptions) (goku.SetOptions, error) {
+	if !o.Ephemeral {
+		return o, nil
+	} else if o.LeaseID != 0 || !o.ExpiresAt.IsZero() {
+		return goku.SetOptions{}, errors.New("ephemeral keys cannot have lease id or expires at")
+	}
+
+	c, ok := ctx.Value(connKey{}).(*conn)
+	if !ok {
+		return goku.SetOptions{}, errors.Wrap(goku.ErrEphemeralUnsupported, "server stats handler not registered")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return goku.SetOptions{}, errors.New("connection closed")
+	}
+
+	if c.leaseID != 0 {
+		l, err := s.store.GetLease(ctx, c.leaseID)
+		if errors.Is(err, goku.ErrLeaseNotFound) || (err == nil && l.Expired) {
+			// The lease expired, grant a new lease below.
+			c.leaseID = 0
+		} else if err != nil {
+			return goku.SetOptions{}, err
+		}
+	}
+
+	if c.leaseID == 0 {
+		err := s.grantConnLocked(ctx, c)
+		if err != nil {
+			return goku.SetOptions{}, err
+		}
+	}
+
+	o.LeaseID = c.leaseID
+	o.ExpiresAt = c.expiresAt
+	o.Ephemeral = false
+
+	return o, nil
+}
+
+// closeConn expires the ephemeral lease of the connection (if any), deleting all its key-values.
+func (s *Server) closeConn(ctx context.Context, c *conn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	if c.leaseID == 0 {
+		return
+	}
+
+	var err error
+	for i := 0; i < 3; i++ {
+		err = s.store.ExpireLease(ctx, c.leaseID)
+		if errors.Is(err, goku.ErrUpdateRace) {
+			continue
+		} else if errors.Is(err, goku.ErrLeaseNotFound) {
+			// Already expired.
+			return
+		}
+		break
+	}
+
+	if err != nil {
+		// ReturnNoErr: The lease will expire after the ttl.
+		log.Error(ctx, errors.Wrap(err, "expire ephemeral lease", j.KV("lease_id", c.leaseID)))
+	}
+}
+
+// keepEphemeralAlive periodically extends the ephemeral leases of all open connections until the server is stopped.
+func (s *Server) keepEphemeralAlive() {
+	t := time.NewTicker(s.ephemeralTTL / 3)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-t.C:
+		}
+
+		s.connMu.Lock()
+		var cl []*conn
+		for c := range s.conns {
+			cl = append(cl, c)
+		}
+		s.connMu.Unlock()
+
+		for _, c := range cl {
+			s.extendConn(context.Background(), c)
+		}
+	}
+}
+
+func (s *Server) extendConn(ctx context.Context, c *conn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed || c.leaseID == 0 {
+		return
+	}
+
+	leaseID := c.leaseID
+	expiresAt := time.Now().Add(s.ephemeralTTL)
+	err := s.store.UpdateLease(ctx, leaseID, expiresAt)
+	if errors.Is(err, goku.ErrLeaseNotFound) {
+		// The lease expired, so grant a new lease.
+		err = s.grantConnLocked(ctx, c)
+	} else if err == nil {
+		c.expiresAt = expiresAt
+	}
+
+	if err != nil {
+		// ReturnNoErr: Try again next period.
+		log.Error(ctx, errors.Wrap(err, "extend ephemeral lease", j.KV("lease_id", leaseID)))
+	}
+}
+
+// grantConnLocked grants a new ephemeral lease for the connection. It resets the connection
+// lease on error. The connection mutex must be held.
+func (s *Server) grantConnLocked(ctx context.Context, c *conn) error {
+	c.leaseID = 0
+
+	expiresAt := time.Now().Add(s.ephemeralTTL)
+	id, err := s.store.GrantLease(ctx, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	c.leaseID = id
+	c.expiresAt = expiresAt
+
+	return nil
+}
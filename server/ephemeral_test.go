@@ -0,0 +1,30 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithEphemeralTTL(t *testing.T) {
+	tests := []struct {
+		Name     string
+		TTL      time.Duration
+		Expected time.Duration
+	}{
+		{Name: "valid", TTL: time.Minute, Expected: time.Minute},
+		{Name: "min", TTL: 3, Expected: 3},
+		{Name: "too short", TTL: 2, Expected: defaultEphemeralTTL},
+		{Name: "zero", TTL: 0, Expected: defaultEphemeralTTL},
+		{Name: "negative", TTL: -time.Second, Expected: defaultEphemeralTTL},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			s := &Server{ephemeralTTL: defaultEphemeralTTL}
+			WithEphemeralTTL(test.TTL)(s)
+			require.Equal(t, test.Expected, s.ephemeralTTL)
+		})
+	}
+}
@@ -2,42 +2,64 @@ package server
 
 import (
 	"context"
-	"database/sql"
-	"strings"
+	"io"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/corverroos/goku"
-	"github.com/corverroos/goku/db"
 	pb "github.com/corverroos/goku/gokupb"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/luno/reflex"
+	"github.com/luno/reflex/reflexpb"
 )
 
 var _ pb.GokuServer = (*Server)(nil)
 
 // Server implements the addresses grpc server.
 type Server struct {
-	rserver    *reflex.Server
-	wdbc, rdbc *sql.DB
+	rserver *reflex.Server
+	store   goku.Store
+
+	ephemeralTTL time.Duration
+	connMu       sync.Mutex
+	conns        map[*conn]bool
+	stop         chan struct{}
+	stopOnce     sync.Once
 }
 
-func New(wdbc, rdbc *sql.DB) *Server {
-	if rdbc == nil {
-		rdbc = wdbc
+// Option configures a Server.
+type Option func(*Server)
+
+// New returns a new goku grpc server backed by the store, see db.NewStore.
+func New(store goku.Store, opts ...Option) *Server {
+	s := &Server{
+		store:        store,
+		rserver:      reflex.NewServer(),
+		ephemeralTTL: defaultEphemeralTTL,
+		conns:        make(map[*conn]bool),
+		stop:         make(chan struct{}),
 	}
 
-	return &Server{
-		wdbc:    wdbc,
-		rdbc:    rdbc,
-		rserver: reflex.NewServer(),
+	for _, opt := range opts {
+		opt(s)
 	}
+
+	go s.keepEphemeralAlive()
+
+	return s
 }
 
 func (srv *Server) Stop() {
-	srv.rserver.Stop()
+	srv.stopOnce.Do(func() {
+		srv.rserver.Stop()
+		close(srv.stop)
+	})
 }
 
 func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (*pb.KV, error) {
-	kv, err := db.Get(ctx, s.rdbc, req.Key)
+	kv, err := s.store.Get(ctx, req.Key)
 	if err != nil {
 		return nil, err
 	}
@@ -45,31 +67,198 @@ func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (*pb.KV, error) {
 	return pb.ToProto(kv), nil
 }
 
+func (s *Server) GetMany(ctx context.Context, req *pb.GetManyRequest) (*pb.GetManyResponse, error) {
+	kvs, err := s.store.GetMany(ctx, req.Keys)
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(pb.GetManyResponse)
+	for _, kv := range kvs {
+		res.Kvs = append(res.Kvs, pb.ToProto(kv))
+	}
+
+	return res, nil
+}
+
 func (s *Server) List(req *pb.ListRequest, lspb pb.Goku_ListServer) error {
 	fn := func(kv goku.KV) error {
 		return lspb.Send(pb.ToProto(kv))
 	}
-	return db.List(lspb.Context(), s.rdbc, req.Prefix, fn)
+	return s.store.List(lspb.Context(), req.Prefix, goku.ListOptions{
+		Limit:      req.Limit,
+		StartAfter: req.StartAfter,
+		EndKey:     req.EndKey,
+		Reverse:    req.Reverse,
+		KeysOnly:   req.KeysOnly,
+	}, fn)
+}
+
+func (s *Server) ListSnapshot(req *pb.ListSnapshotRequest, lspb pb.Goku_ListSnapshotServer) error {
+	snap, err := s.store.ListSnapshot(lspb.Context(), req.Prefix)
+	if err != nil {
+		return err
+	}
+
+	err = lspb.Send(&pb.ListSnapshotResponse{Ref: snap.Ref, Pending: snap.Pending})
+	if err != nil {
+		return err
+	}
+
+	for _, kv := range snap.KVs {
+		err := lspb.Send(&pb.ListSnapshotResponse{Kv: pb.ToProto(kv)})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) GetAt(ctx context.Context, req *pb.GetAtRequest) (*pb.KV, error) {
+	kv, err := s.store.GetAt(ctx, req.Key, req.Ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return pb.ToProto(kv), nil
+}
+
+func (s *Server) ListAt(req *pb.ListAtRequest, lspb pb.Goku_ListAtServer) error {
+	fn := func(kv goku.KV) error {
+		return lspb.Send(pb.ToProto(kv))
+	}
+	return s.store.ListAt(lspb.Context(), req.Prefix, req.Ref, fn)
+}
+
+func (s *Server) History(req *pb.HistoryRequest, hspb pb.Goku_HistoryServer) error {
+	fn := func(r goku.Revision) error {
+		rpb, err := pb.RevisionToProto(r)
+		if err != nil {
+			return err
+		}
+		return hspb.Send(rpb)
+	}
+
+	return s.store.History(hspb.Context(), req.Key, goku.HistoryOptions{
+		Limit:    req.Limit,
+		AfterRef: req.AfterRef,
+		Reverse:  req.Reverse,
+	}, fn)
 }
 
 func (s *Server) Set(ctx context.Context, req *pb.SetRequest) (*pb.Empty, error) {
+	item, err := s.setItemFromProto(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(pb.Empty), s.store.Set(ctx, item.Key, item.Value, item.SetOptions)
+}
+
+func (s *Server) SetMany(ctx context.Context, req *pb.SetManyRequest) (*pb.Empty, error) {
+	var items []goku.SetItem
+	for _, itempb := range req.Items {
+		item, err := s.setItemFromProto(ctx, itempb)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return new(pb.Empty), s.store.SetMany(ctx, items)
+}
+
+// setItemFromProto returns the set item with ephemeral options resolved to the connection's lease.
+func (s *Server) setItemFromProto(ctx context.Context, req *pb.SetRequest) (goku.SetItem, error) {
+	expiresAt, err := ptypes.Timestamp(req.ExpiresAt)
+	if err != nil {
+		return goku.SetItem{}, err
+	}
+
+	o, err := s.resolveEphemeral(ctx, goku.SetOptions{
+		ExpiresAt: expiresAt,
+		LeaseID:   req.LeaseId,
+		Ephemeral: req.Ephemeral,
+	})
+	if err != nil {
+		return goku.SetItem{}, err
+	}
+
+	o.PrevVersion = req.PrevVersion
+	o.CreateOnly = req.CreateOnly
+
+	return goku.SetItem{
+		Key:        req.Key,
+		Value:      req.Value,
+		SetOptions: o,
+	}, nil
+}
+
+func (s *Server) Create(ctx context.Context, req *pb.CreateRequest) (*pb.CreateResponse, error) {
 	expiresAt, err := ptypes.Timestamp(req.ExpiresAt)
 	if err != nil {
 		return nil, err
 	}
 
-	return new(pb.Empty), db.Set(ctx, s.wdbc, db.SetReq{
-		Key:         req.Key,
-		Value:       req.Value,
-		LeaseID:     req.LeaseId,
-		ExpiresAt:   expiresAt,
-		PrevVersion: req.PrevVersion,
-		CreateOnly:  req.CreateOnly,
+	o, err := s.resolveEphemeral(ctx, goku.SetOptions{
+		ExpiresAt: expiresAt,
+		LeaseID:   req.LeaseId,
+		Ephemeral: req.Ephemeral,
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.store.Create(ctx, req.Prefix, req.Value, o)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CreateResponse{Key: key}, nil
+}
+
+func (s *Server) Increment(ctx context.Context, req *pb.IncrementRequest) (*pb.IncrementResponse, error) {
+	val, err := s.store.Increment(ctx, req.Key, req.Delta)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.IncrementResponse{Value: val}, nil
 }
 
-func (s *Server) Delete(ctx context.Context, req *pb.DeleteRequest) (*pb.Empty, error) {
-	return new(pb.Empty), db.Delete(ctx, s.wdbc, req.Key)
+func (s *Server) Delete(ctx context.Context, req *pb.DeleteRequest) (*pb.DeleteResponse, error) {
+	o := goku.DeleteOptions{
+		PrevVersion: req.PrevVersion,
+		LeaseID:     req.LeaseId,
+	}
+
+	var prev goku.KV
+	if req.ReturnPrev {
+		o.Prev = &prev
+	}
+
+	err := s.store.Delete(ctx, req.Key, o)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.ReturnPrev {
+		return new(pb.DeleteResponse), nil
+	}
+
+	return &pb.DeleteResponse{Prev: pb.ToProto(prev)}, nil
+}
+
+func (s *Server) DeletePrefix(ctx context.Context, req *pb.DeletePrefixRequest) (*pb.DeletePrefixResponse, error) {
+	n, err := s.store.DeletePrefix(ctx, req.Prefix, goku.DeletePrefixOptions{
+		BatchSize: req.BatchSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.DeletePrefixResponse{Deleted: n}, nil
 }
 
 func (s *Server) UpdateLease(ctx context.Context, req *pb.UpdateLeaseRequest) (*pb.Empty, error) {
@@ -78,41 +267,195 @@ func (s *Server) UpdateLease(ctx context.Context, req *pb.UpdateLeaseRequest) (*
 		return nil, err
 	}
 
-	return new(pb.Empty), db.UpdateLease(ctx, s.wdbc, req.LeaseId, expiresAt)
+	return new(pb.Empty), s.store.UpdateLease(ctx, req.LeaseId, expiresAt)
 }
 
 func (s *Server) ExpireLease(ctx context.Context, req *pb.ExpireLeaseRequest) (*pb.Empty, error) {
-	return new(pb.Empty), db.ExpireLease(ctx, s.wdbc, req.LeaseId)
+	return new(pb.Empty), s.store.ExpireLease(ctx, req.LeaseId)
 }
 
-func (s *Server) Stream(req *pb.StreamRequest, sspb pb.Goku_StreamServer) error {
-	streamFunc := func(ctx context.Context, after string, opts ...reflex.StreamOption) (reflex.StreamClient, error) {
-		cl, err := db.ToStream(s.rdbc)(ctx, after, opts...)
+func (s *Server) GrantLease(ctx context.Context, req *pb.GrantLeaseRequest) (*pb.GrantLeaseResponse, error) {
+	expiresAt, err := ptypes.Timestamp(req.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := s.store.GrantLease(ctx, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GrantLeaseResponse{LeaseId: id}, nil
+}
+
+func (s *Server) GetLease(ctx context.Context, req *pb.GetLeaseRequest) (*pb.Lease, error) {
+	l, err := s.store.GetLease(ctx, req.LeaseId)
+	if err != nil {
+		return nil, err
+	}
+
+	return pb.LeaseToProto(l)
+}
+
+func (s *Server) ListLeaseKeys(req *pb.ListLeaseKeysRequest, lspb pb.Goku_ListLeaseKeysServer) error {
+	fn := func(kv goku.KV) error {
+		return lspb.Send(pb.ToProto(kv))
+	}
+	return s.store.ListLeaseKeys(lspb.Context(), req.LeaseId, fn)
+}
+
+func (s *Server) ListLeases(req *pb.ListLeasesRequest, lspb pb.Goku_ListLeasesServer) error {
+	expiresBefore, err := ptypes.Timestamp(req.ExpiresBefore)
+	if err != nil {
+		return err
+	}
+
+	ll, err := s.store.ListLeases(lspb.Context(), goku.ListLeasesOptions{
+		ExpiresBefore:  expiresBefore,
+		IncludeExpired: req.IncludeExpired,
+		AfterID:        req.AfterId,
+		Limit:          req.Limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, l := range ll {
+		lpb, err := pb.LeaseToProto(l)
+		if err != nil {
+			return err
+		}
+
+		err = lspb.Send(lpb)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) Txn(ctx context.Context, req *pb.TxnRequest) (*pb.TxnResponse, error) {
+	var (
+		compares  []goku.Compare
+		then, els []goku.Op
+	)
+	for _, c := range req.Compares {
+		compares = append(compares, pb.CompareFromProto(c))
+	}
+
+	for _, op := range req.Then {
+		o, err := s.opFromProto(ctx, op)
 		if err != nil {
 			return nil, err
 		}
+		then = append(then, o)
+	}
 
-		return &prefixFilter{
-			prefix: req.Prefix,
-			cl:     cl,
-		}, nil
+	for _, op := range req.Else {
+		o, err := s.opFromProto(ctx, op)
+		if err != nil {
+			return nil, err
+		}
+		els = append(els, o)
+	}
+
+	resp, err := s.store.Txn(ctx, compares, then, els)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.TxnResponse{Succeeded: resp.Succeeded}
+	for _, kv := range resp.Gets {
+		res.Gets = append(res.Gets, pb.ToProto(kv))
+	}
+
+	return res, nil
+}
+
+// opFromProto returns the op with ephemeral set options resolved to the connection's lease.
+func (s *Server) opFromProto(ctx context.Context, op *pb.Op) (goku.Op, error) {
+	o, err := pb.OpFromProto(op)
+	if err != nil {
+		return goku.Op{}, err
+	}
+
+	o.SetOptions, err = s.resolveEphemeral(ctx, o.SetOptions)
+	if err != nil {
+		return goku.Op{}, err
 	}
 
-	return s.rserver.Stream(streamFunc, req.Req, sspb)
+	return o, nil
 }
 
-type prefixFilter struct {
-	prefix string
-	cl     reflex.StreamClient
+func (s *Server) Stream(req *pb.StreamRequest, sspb pb.Goku_StreamServer) error {
+	return s.rserver.Stream(s.store.Stream(req.Prefix), req.Req, sspb)
 }
 
-func (f *prefixFilter) Recv() (*reflex.Event, error) {
-	for {
-		e, err := f.cl.Recv()
+func (s *Server) Watch(req *pb.WatchRequest, wspb pb.Goku_WatchServer) error {
+	wFn := s.store.WatchStream(req.Prefix)
+
+	sFn := func(ctx context.Context, after string, opts ...reflex.StreamOption) (reflex.StreamClient, error) {
+		wc, err := wFn(ctx, after, opts...)
 		if err != nil {
 			return nil, err
-		} else if strings.HasPrefix(e.ForeignID, f.prefix) {
-			return e, nil
 		}
+
+		return &watchEncoder{wc: wc}, nil
 	}
+
+	return s.rserver.Stream(sFn, req.Req, &watchServer{Goku_WatchServer: wspb})
+}
+
+// watchEncoder adapts a typed goku event stream to a reflex stream for the reflex stream server.
+// Typed events are encoded as proto in the reflex event metadata, see watchServer.
+type watchEncoder struct {
+	wc goku.WatchStreamClient
+}
+
+func (e *watchEncoder) Recv() (*reflex.Event, error) {
+	we, err := e.wc.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	wepb, err := pb.WatchEventToProto(we)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := proto.Marshal(wepb)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reflex.Event{
+		ID:        strconv.FormatInt(we.Ref, 10),
+		Type:      we.Type,
+		ForeignID: we.Key,
+		Timestamp: we.Timestamp,
+		MetaData:  b,
+	}, nil
+}
+
+func (e *watchEncoder) Close() error {
+	if c, ok := e.wc.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
+
+// watchServer adapts a reflex stream server to send the typed goku events encoded by watchEncoder.
+type watchServer struct {
+	pb.Goku_WatchServer
+}
+
+func (s *watchServer) Send(e *reflexpb.Event) error {
+	var wepb pb.WatchEvent
+	if err := proto.Unmarshal(e.Metadata, &wepb); err != nil {
+		return err
+	}
+
+	return s.Goku_WatchServer.Send(&wepb)
 }
@@ -0,0 +1,82 @@
+package goku
+
+import (
+	"context"
+	"time"
+
+	"github.com/luno/reflex"
+)
+
+// Store defines the storage backend of the goku server and logical client, see db.Store.
+// Options are provided as structs and lists are returned via callbacks, so stores
+// can be wrapped by decorators, eg. for caching or fault injection.
+//
+// Stores do not support ephemeral key-values, the server resolves them to leases.
+type Store interface {
+	// Set creates or updates a key-value.
+	Set(ctx context.Context, key string, value []byte, opts SetOptions) error
+
+	// SetMany atomically creates or updates multiple key-values.
+	SetMany(ctx context.Context, items []SetItem) error
+
+	// Create creates a key-value with the next sequential key of the prefix and returns the key.
+	Create(ctx context.Context, prefix string, value []byte, opts SetOptions) (string, error)
+
+	// Increment atomically adds the delta to the integer value of the key-value and returns the new value.
+	Increment(ctx context.Context, key string, delta int64) (int64, error)
+
+	// Delete soft-deletes the key-value. It populates Prev with the deleted key-value if provided.
+	Delete(ctx context.Context, key string, opts DeleteOptions) error
+
+	// DeletePrefix soft-deletes all key-values with keys matching the prefix and returns the number deleted.
+	DeletePrefix(ctx context.Context, prefix string, opts DeletePrefixOptions) (int64, error)
+
+	// Get returns the key-value for the given key.
+	Get(ctx context.Context, key string) (KV, error)
+
+	// GetMany returns the key-values for the given keys in the same order as the keys.
+	GetMany(ctx context.Context, keys []string) ([]KV, error)
+
+	// List calls fn with the key-values with keys matching the prefix ordered by key.
+	List(ctx context.Context, prefix string, opts ListOptions, fn func(KV) error) error
+
+	// ListSnapshot returns a consistent snapshot of the key-values with keys matching the prefix.
+	ListSnapshot(ctx context.Context, prefix string) (Snapshot, error)
+
+	// GetAt returns the key-value for the given key as it was after the event with id ref.
+	GetAt(ctx context.Context, key string, ref int64) (KV, error)
+
+	// ListAt calls fn with the key-values with keys matching the prefix as they were after the event with id ref.
+	ListAt(ctx context.Context, prefix string, ref int64, fn func(KV) error) error
+
+	// History calls fn with the revisions of the given key ordered by ref.
+	History(ctx context.Context, key string, opts HistoryOptions, fn func(Revision) error) error
+
+	// Txn atomically executes the "then" operations if all the compares are true,
+	// otherwise it executes the "else" operations.
+	Txn(ctx context.Context, compares []Compare, then []Op, els []Op) (TxnResponse, error)
+
+	// GrantLease creates a new lease and returns its id.
+	GrantLease(ctx context.Context, expiresAt time.Time) (int64, error)
+
+	// UpdateLease updates the expires at of the given lease.
+	UpdateLease(ctx context.Context, leaseID int64, expiresAt time.Time) error
+
+	// ExpireLease expires the given lease and deletes all key-values associated with it.
+	ExpireLease(ctx context.Context, leaseID int64) error
+
+	// GetLease returns the lease for the given id.
+	GetLease(ctx context.Context, leaseID int64) (Lease, error)
+
+	// ListLeaseKeys calls fn with the key-values associated with the given lease.
+	ListLeaseKeys(ctx context.Context, leaseID int64, fn func(KV) error) error
+
+	// ListLeases returns leases ordered by id filtered by the options.
+	ListLeases(ctx context.Context, opts ListLeasesOptions) ([]Lease, error)
+
+	// Stream returns a reflex stream function of events for keys matching the prefix.
+	Stream(prefix string) reflex.StreamFunc
+
+	// WatchStream returns a stream function of typed events for keys matching the prefix.
+	WatchStream(prefix string) WatchStreamFunc
+}
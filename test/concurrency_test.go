@@ -0,0 +1,136 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/corverroos/goku"
+	"github.com/corverroos/goku/concurrency"
+	"github.com/luno/jettison/jtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMutex(t *testing.T) {
+	ctx := context.Background()
+	cl, _ := SetupForTesting(t)
+
+	const key = "lock"
+
+	m1 := concurrency.NewMutex(cl, key, time.Minute)
+	m2 := concurrency.NewMutex(cl, key, time.Minute)
+
+	token1, err := m1.TryLock(ctx)
+	jtest.RequireNil(t, err)
+
+	_, err = m2.TryLock(ctx)
+	jtest.Require(t, concurrency.ErrLocked, err)
+
+	locked := make(chan int64)
+	go func() {
+		token, err := m2.Lock(ctx)
+		jtest.RequireNil(t, err)
+		locked <- token
+	}()
+
+	select {
+	case <-locked:
+		require.Fail(t, "locked while held")
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	err = m1.Unlock(ctx)
+	jtest.RequireNil(t, err)
+
+	token2 := <-locked
+	require.Greater(t, token2, token1)
+
+	err = m1.Unlock(ctx)
+	jtest.Require(t, concurrency.ErrNotLocked, err)
+
+	err = m2.Unlock(ctx)
+	jtest.RequireNil(t, err)
+}
+
+func TestElection(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cl, _ := SetupForTesting(t)
+
+	const key = "leader"
+
+	e1 := concurrency.NewElection(cl, key, time.Minute)
+	e2 := concurrency.NewElection(cl, key, time.Minute)
+
+	_, err := e1.Leader(ctx)
+	jtest.Require(t, concurrency.ErrNoLeader, err)
+
+	observed := e1.Observe(ctx)
+
+	lctx1, err := e1.Campaign(ctx, []byte("e1"))
+	jtest.RequireNil(t, err)
+
+	kv, err := e2.Leader(ctx)
+	jtest.RequireNil(t, err)
+	require.Equal(t, "e1", string(kv.Value))
+	require.Equal(t, "e1", string((<-observed).Value))
+
+	elected := make(chan context.Context)
+	go func() {
+		lctx, err := e2.Campaign(ctx, []byte("e2"))
+		jtest.RequireNil(t, err)
+		elected <- lctx
+	}()
+
+	err = e2.Resign(ctx)
+	jtest.Require(t, concurrency.ErrNotLeader, err)
+
+	err = e1.Resign(ctx)
+	jtest.RequireNil(t, err)
+	<-lctx1.Done()
+
+	lctx2 := <-elected
+	require.NoError(t, lctx2.Err())
+	require.Equal(t, "e2", string((<-observed).Value))
+
+	// Expiring the lease steps down.
+	kv, err = e1.Leader(ctx)
+	jtest.RequireNil(t, err)
+	err = cl.ExpireLease(ctx, kv.LeaseID)
+	jtest.RequireNil(t, err)
+	<-lctx2.Done()
+}
+
+func TestSession(t *testing.T) {
+	ctx := context.Background()
+	cl, _ := SetupForTesting(t)
+
+	s, err := concurrency.NewSession(ctx, cl, time.Millisecond*300)
+	jtest.RequireNil(t, err)
+
+	err = cl.Set(ctx, "worker", nil, goku.WithLeaseID(s.LeaseID()))
+	jtest.RequireNil(t, err)
+
+	// Kept alive past the TTL.
+	time.Sleep(time.Second)
+	select {
+	case <-s.Done():
+		require.Fail(t, "session lost")
+	default:
+	}
+
+	err = s.Close(ctx)
+	jtest.RequireNil(t, err)
+	<-s.Done()
+
+	_, err = cl.Get(ctx, "worker")
+	jtest.Require(t, goku.ErrNotFound, err)
+
+	// Sessions are lost when their lease expires.
+	s, err = concurrency.NewSession(ctx, cl, time.Millisecond*300)
+	jtest.RequireNil(t, err)
+
+	err = cl.ExpireLease(ctx, s.LeaseID())
+	jtest.RequireNil(t, err)
+	<-s.Done()
+}
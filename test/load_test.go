@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/corverroos/goku/clienttest"
 	"github.com/luno/jettison/jtest"
 )
 
@@ -58,8 +59,8 @@ func TestLoad(t *testing.T) {
 				go func() {
 					keys := make(map[string]bool)
 					for i := 0; i < test.count; i++ {
-						key := uniqKey(keys)
-						err := cl.Set(ctx, key, []byte(genRand(255)))
+						key := clienttest.UniqKey(keys)
+						err := cl.Set(ctx, key, []byte(clienttest.GenRand(255)))
 						jtest.RequireNil(t, err)
 						keyCh <- key
 					}
@@ -26,17 +26,23 @@ func SetupForTesting(t *testing.T) (*client.Client, *sql.DB) {
 
 	t.Cleanup(srv.Stop)
 
+	cl, conn := Connect(t, addr)
+	t.Cleanup(func() {
+		require.NoError(t, conn.Close())
+	})
+
+	return cl, dbc
+}
+
+// Connect returns a client connected to the goku grpc server at the address. The caller
+// is responsible for closing the connection.
+func Connect(t *testing.T, addr string) (*client.Client, *grpc.ClientConn) {
 	conn, err := grpc.Dial(addr, grpc.WithInsecure(),
 		grpc.WithUnaryInterceptor(interceptors.UnaryClientInterceptor),
 		grpc.WithStreamInterceptor(interceptors.StreamClientInterceptor))
 	jtest.RequireNil(t, err)
 
-	gcl := pb.NewGokuClient(conn)
-	t.Cleanup(func() {
-		require.NoError(t, conn.Close())
-	})
-
-	cl := client.New(gcl)
+	cl := client.New(pb.NewGokuClient(conn))
 
 	// Wait until connected to avoid startup race.
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
@@ -52,7 +58,7 @@ func SetupForTesting(t *testing.T) (*client.Client, *sql.DB) {
 		}
 	}
 
-	return cl, dbc
+	return cl, conn
 }
 
 // NewServer starts and returns a goku grpc server and its address.
@@ -60,11 +66,12 @@ func NewServer(t *testing.T, dbc *sql.DB) (*server.Server, string) {
 	l, err := net.Listen("tcp", "127.0.0.1:0")
 	jtest.RequireNil(t, err)
 
+	srv := server.New(db.NewStore(dbc, dbc))
+
 	grpcServer := grpc.NewServer(
 		grpc.UnaryInterceptor(interceptors.UnaryServerInterceptor),
-		grpc.StreamInterceptor(interceptors.StreamServerInterceptor))
-
-	srv := server.New(dbc, dbc)
+		grpc.StreamInterceptor(interceptors.StreamServerInterceptor),
+		grpc.StatsHandler(srv.StatsHandler()))
 
 	pb.RegisterGokuServer(grpcServer, srv)
 
@@ -0,0 +1,104 @@
+package goku
+
+// CompareType defines the key-value field a Compare predicate checks.
+type CompareType int
+
+const (
+	CompareTypeUnknown    CompareType = 0
+	CompareTypeVersion    CompareType = 1
+	CompareTypeCreatedRef CompareType = 2
+	CompareTypeExists     CompareType = 3
+	CompareTypeLeaseID    CompareType = 4
+)
+
+// Compare is a predicate on the current state of a key used by Txn. Deleted (or
+// non-existent) key-values are compared as zero values, so
+// CompareVersion(key, 0) is true if the key doesn't exist.
+type Compare struct {
+	Key   string
+	Type  CompareType
+	Value int64
+}
+
+// CompareVersion returns a predicate that is true if the key's version equals the provided version.
+func CompareVersion(key string, version int64) Compare {
+	return Compare{Key: key, Type: CompareTypeVersion, Value: version}
+}
+
+// CompareCreatedRef returns a predicate that is true if the key's created ref equals the provided ref.
+func CompareCreatedRef(key string, ref int64) Compare {
+	return Compare{Key: key, Type: CompareTypeCreatedRef, Value: ref}
+}
+
+// CompareExists returns a predicate that is true if the key exists (and isn't deleted) iff exists is true.
+func CompareExists(key string, exists bool) Compare {
+	var val int64
+	if exists {
+		val = 1
+	}
+	return Compare{Key: key, Type: CompareTypeExists, Value: val}
+}
+
+// CompareLeaseID returns a predicate that is true if the key's lease id equals the provided lease id.
+func CompareLeaseID(key string, leaseID int64) Compare {
+	return Compare{Key: key, Type: CompareTypeLeaseID, Value: leaseID}
+}
+
+// OpType defines the type of operation executed by Txn.
+type OpType int
+
+const (
+	OpTypeUnknown OpType = 0
+	OpTypeGet     OpType = 1
+	OpTypeSet     OpType = 2
+	OpTypeDelete  OpType = 3
+)
+
+// Op is an operation executed by Txn.
+type Op struct {
+	Type  OpType
+	Key   string
+	Value []byte
+
+	// SetOptions only applies to set operations.
+	SetOptions SetOptions
+
+	// DeleteOptions only applies to delete operations. Prev is not populated, use OpGet instead.
+	DeleteOptions DeleteOptions
+}
+
+// OpGet returns an operation that gets the key-value for the key.
+func OpGet(key string) Op {
+	return Op{Type: OpTypeGet, Key: key}
+}
+
+// OpSet returns an operation that creates or updates the key-value with options.
+func OpSet(key string, value []byte, opts ...SetOption) Op {
+	var o SetOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return Op{Type: OpTypeSet, Key: key, Value: value, SetOptions: o}
+}
+
+// OpDelete returns an operation that soft-deletes the key-value for the key with options.
+func OpDelete(key string, opts ...DeleteOption) Op {
+	var o DeleteOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return Op{Type: OpTypeDelete, Key: key, DeleteOptions: o}
+}
+
+// TxnResponse is the result of a Txn.
+type TxnResponse struct {
+	// Succeeded is true if all compares were true and the "then" operations were executed.
+	// It is false if the "else" operations were executed.
+	Succeeded bool
+
+	// Gets contains the results of the executed get operations in order. A zero KV
+	// is returned for keys that were not found.
+	Gets []KV
+}
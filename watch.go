@@ -0,0 +1,175 @@
+package goku
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/luno/reflex"
+)
+
+// Snapshot is a consistent snapshot of the key-values matching a prefix.
+type Snapshot struct {
+	// KVs are the key-values matching the prefix ordered by key.
+	KVs []KV
+
+	// Ref is the id of the latest event reflected in the snapshot.
+	Ref int64
+
+	// Pending are the ids (ascending) lower than Ref of events not reflected in the snapshot
+	// since their transactions were still in flight when the snapshot was taken.
+	Pending []int64
+}
+
+// After returns the ref to stream after so that no event not reflected in the snapshot is missed.
+func (s Snapshot) After() int64 {
+	if len(s.Pending) > 0 {
+		return s.Pending[0] - 1
+	}
+
+	return s.Ref
+}
+
+// Reflects returns true if the event with id ref is reflected in the snapshot.
+func (s Snapshot) Reflects(ref int64) bool {
+	if ref > s.Ref {
+		return false
+	}
+
+	for _, p := range s.Pending {
+		if p == ref {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Watch returns a consistent snapshot of the key-values matching the prefix and a stream of all
+// subsequent events of keys matching the prefix. Events reflected in the snapshot are not streamed,
+// so applying the streamed events to the snapshot results in a gap-free view of the prefix.
+func Watch(ctx context.Context, cl Client, prefix string, opts ...reflex.StreamOption) (Snapshot, reflex.StreamClient, error) {
+	snap, err := cl.ListSnapshot(ctx, prefix)
+	if err != nil {
+		return Snapshot{}, nil, err
+	}
+
+	sc, err := cl.Stream(prefix)(ctx, strconv.FormatInt(snap.After(), 10), opts...)
+	if err != nil {
+		return Snapshot{}, nil, err
+	}
+
+	return snap, &watchStream{StreamClient: sc, snap: snap}, nil
+}
+
+// watchStream skips events reflected in the snapshot.
+type watchStream struct {
+	reflex.StreamClient
+	snap Snapshot
+}
+
+func (s *watchStream) Recv() (*reflex.Event, error) {
+	for {
+		e, err := s.StreamClient.Recv()
+		if err != nil {
+			return nil, err
+		}
+
+		if s.snap.Reflects(e.IDInt()) {
+			continue
+		}
+
+		return e, nil
+	}
+}
+
+func (s *watchStream) Close() error {
+	if c, ok := s.StreamClient.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
+
+// WatchEvent is a typed goku event with the state of the key-value after the event.
+type WatchEvent struct {
+	// Key of the key-value.
+	Key string
+
+	// Type of the event; set, delete or expire.
+	Type EventType
+
+	// Value of the key-value after the event. It is always empty for delete and expire events.
+	Value []byte
+
+	// Version of the key-value after the event.
+	Version int64
+
+	// LeaseID is the id of the lease associated with the key-value.
+	LeaseID int64
+
+	// Ref is the id of the event.
+	Ref int64
+
+	// Timestamp of the event.
+	Timestamp time.Time
+}
+
+// WatchStreamClient is a stream of typed goku events.
+type WatchStreamClient interface {
+	// Recv blocks until the next event is found.
+	Recv() (WatchEvent, error)
+}
+
+// WatchStreamFunc returns a stream of typed goku events after the provided cursor (event id).
+type WatchStreamFunc func(ctx context.Context, after string, opts ...reflex.StreamOption) (WatchStreamClient, error)
+
+// DecodeEvent returns the typed goku event of the reflex event. Note that Version and LeaseID
+// are not populated since reflex events do not contain them, use Client.WatchStream instead.
+func DecodeEvent(e *reflex.Event) (WatchEvent, error) {
+	ref, err := strconv.ParseInt(e.ID, 10, 64)
+	if err != nil {
+		return WatchEvent{}, err
+	}
+
+	we := WatchEvent{
+		Key:       e.ForeignID,
+		Type:      EventType(e.Type.ReflexType()),
+		Ref:       ref,
+		Timestamp: e.Timestamp,
+	}
+
+	if we.Type == EventTypeSet {
+		we.Value = e.MetaData
+	}
+
+	return we, nil
+}
+
+// NewWatchStream returns a stream of typed goku events decoded from the reflex stream of goku events.
+// See DecodeEvent for details.
+func NewWatchStream(sc reflex.StreamClient) WatchStreamClient {
+	return &decodeStream{sc: sc}
+}
+
+type decodeStream struct {
+	sc reflex.StreamClient
+}
+
+func (s *decodeStream) Recv() (WatchEvent, error) {
+	e, err := s.sc.Recv()
+	if err != nil {
+		return WatchEvent{}, err
+	}
+
+	return DecodeEvent(e)
+}
+
+func (s *decodeStream) Close() error {
+	if c, ok := s.sc.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}